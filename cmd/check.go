@@ -22,9 +22,16 @@ import (
 	"github.com/turbot/steampipe/control"
 	"github.com/turbot/steampipe/control/controldisplay"
 	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/control/controlhistory"
+	"github.com/turbot/steampipe/control/controlnotify"
 	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/db/db_client"
+	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/db/db_local"
 	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/filepaths"
 	"github.com/turbot/steampipe/statushooks"
+	"github.com/turbot/steampipe/steampipeconfig"
 	"github.com/turbot/steampipe/utils"
 	"github.com/turbot/steampipe/workspace"
 )
@@ -71,15 +78,34 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 		AddBoolFlag(constants.ArgProgress, "", true, "Display control execution progress").
 		AddBoolFlag(constants.ArgDryRun, "", false, "Show which controls will be run without running them").
 		AddStringSliceFlag(constants.ArgTag, "", nil, "Filter controls based on their tag values ('--tag key=value')").
+		AddStringFlag(constants.ArgTagFilter, "", "", "Filter controls using a tag expression ('--tag-filter \"cis_level=1 && !(service in [iam, s3])\"'), supporting '&&', '||', '!', 'in [...]' and '*' wildcards").
 		AddStringSliceFlag(constants.ArgVarFile, "", nil, "Specify an .spvar file containing variable values").
 		// NOTE: use StringArrayFlag for ArgVariable, not StringSliceFlag
 		// Cobra will interpret values passed to a StringSliceFlag as CSV,
 		// where args passed to StringArrayFlag are not parsed and used raw
 		AddStringArrayFlag(constants.ArgVariable, "", nil, "Specify the value of a variable").
-		AddStringFlag(constants.ArgWhere, "", "", "SQL 'where' clause, or named query, used to filter controls (cannot be used with '--tag')").
+		AddStringFlag(constants.ArgWhere, "", "", "SQL 'where' clause, or named query, used to filter controls (cannot be used with '--tag' or '--tag-filter')").
 		AddIntFlag(constants.ArgMaxParallel, "", constants.DefaultMaxConnections, "The maximum number of parallel executions", cmdconfig.FlagOptions.Hidden()).
 		AddBoolFlag(constants.ArgModInstall, "", true, "Specify whether to install mod dependencies before running the check").
-		AddBoolFlag(constants.ArgInput, "", true, "Enable interactive prompts")
+		AddBoolFlag(constants.ArgInput, "", true, "Enable interactive prompts").
+		AddStringFlag(constants.ArgCheckpoint, "", "", "Persist control results to a checkpoint file, and skip controls which already succeeded when re-running with the same file").
+		AddStringFlag(constants.ArgShard, "", "", "Only run the subset of controls allocated to shard N of M ('--shard N/M')").
+		AddStringFlag(constants.ArgGroupBy, "", "", "Break down results by a dimension or tag value for text, html and md output ('--group-by dimension:account_id' or '--group-by tag:service')").
+		AddStringArrayFlag(constants.ArgExitPolicy, "", nil, "Determine which results set a failing exit code ('--exit-policy severity=critical,high', '--exit-policy status=alarm', '--exit-policy max-failure-rate=5' or '--exit-policy none')").
+		AddStringFlag(constants.ArgRemediationScript, "", "", "Write a script containing the remediation command for every alarmed resource to this file (the script is not run)").
+		AddBoolFlag(constants.ArgNoResultCache, "", false, "Do not reuse cached results of unchanged controls from previous check runs").
+		AddIntFlag(constants.ArgResultCacheTTL, "", constants.DefaultResultCacheTTLSecs, "Reuse the cached results of unchanged controls from previous check runs for this number of seconds (0 disables the result cache)").
+		AddBoolFlag(constants.ArgHistory, "", false, "Persist the results of the check run to the check history store in the service database").
+		AddBoolFlag(constants.ArgResultTable, "", false, "Load the check results into the 'steampipe_check_result' table in the service database, replacing any previous results").
+		AddBoolFlag(constants.ArgProfile, "", false, "Record the timing of each control and report the slowest controls and queries").
+		AddIntFlag(constants.ArgProfileLimit, "", constants.DefaultProfileLimit, "The number of slowest controls and queries reported by '--profile'").
+		AddIntFlag(constants.ArgCheckDisplayWidth, "", 0, "Render 'text' and 'brief' output with a fixed width, rather than the terminal width").
+		AddStringSliceFlag(constants.ArgCheckDisplayColumns, "", nil, "The result columns shown in 'text' and 'brief' output: status, reason and dimensions (comma-separated)").
+		AddStringSliceFlag(constants.ArgCheckDisplayDimensions, "", nil, "Only show these dimensions in 'text' and 'brief' output (comma-separated)").
+		AddBoolFlag(constants.ArgOnlyFailed, "", false, "Only show controls with alarms or errors in 'text' and 'brief' output")
+
+	cmd.AddCommand(checkMergeCmd())
+	cmd.AddCommand(checkHistoryCmd())
 
 	return cmd
 }
@@ -141,6 +167,12 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 	exportErrorsLock := sync.Mutex{}
 	exportWaitGroup := sync.WaitGroup{}
 	var durations []time.Duration
+	// if a '--remediation-script' file was passed, build a script covering the alarms of every execution
+	var remediationScript *controlexecute.RemediationScript
+	if viper.GetString(constants.ArgRemediationScript) != "" {
+		remediationScript = controlexecute.NewRemediationScript()
+	}
+	resultTableLoaded := false
 
 	// treat each arg as a separate execution
 	for _, arg := range args {
@@ -164,6 +196,27 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		err = displayControlResults(ctx, executionTree)
 		utils.FailOnError(err)
 
+		if shouldPrintProfile() {
+			printProfile(arg, executionTree.Profile)
+		}
+
+		// send notifications to any configured notifiers
+		notifyCheckResult(ctx, arg, executionTree)
+
+		if viper.GetBool(constants.ArgHistory) {
+			addCheckHistory(ctx, client, arg, executionTree)
+		}
+
+		if viper.GetBool(constants.ArgResultTable) {
+			// the results of all args are loaded into the same table - replace the table for the first arg only
+			loadCheckResultTable(ctx, client, arg, executionTree, !resultTableLoaded)
+			resultTableLoaded = true
+		}
+
+		if remediationScript != nil {
+			remediationScript.Add(executionTree)
+		}
+
 		if len(exportTargets) > 0 {
 			d := control.ExportData{
 				ExecutionTree: executionTree,
@@ -185,6 +238,10 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		utils.ShowError(ctx, utils.CombineErrors(exportErrors...))
 	}
 
+	if remediationScript != nil && !viper.GetBool(constants.ArgDryRun) {
+		writeRemediationScript(ctx, remediationScript)
+	}
+
 	if shouldPrintTiming() {
 		printTiming(args, durations)
 	}
@@ -262,6 +319,54 @@ func printTiming(args []string, durations []time.Duration) {
 	display.ShowWrappedTable(headers, rows, false)
 }
 
+// print the slowest controls and queries of the execution
+func printProfile(arg string, profile *controlexecute.ExecutionProfile) {
+	fmt.Println()
+	fmt.Printf("Slowest controls for %s:\n", arg)
+	var controlRows [][]string
+	for _, c := range profile.SlowestControls {
+		cached := ""
+		if c.CacheHit {
+			cached = "yes"
+		}
+		controlRows = append(controlRows, []string{
+			c.ControlId,
+			formatProfileMs(c.DurationMs),
+			formatProfileMs(c.QueueMs),
+			formatProfileMs(c.QueryMs),
+			fmt.Sprintf("%d", c.RowCount),
+			cached,
+		})
+	}
+	display.ShowWrappedTable([]string{"Control", "Duration", "Queued", "Query", "Rows", "Cached"}, controlRows, false)
+
+	fmt.Println()
+	fmt.Printf("Slowest queries for %s:\n", arg)
+	var queryRows [][]string
+	for _, q := range profile.SlowestQueries {
+		queryRows = append(queryRows, []string{
+			q.Query,
+			fmt.Sprintf("%d", q.Executions),
+			formatProfileMs(q.TotalQueryMs),
+			formatProfileMs(q.MaxQueryMs),
+			fmt.Sprintf("%d", q.RowCount),
+		})
+	}
+	display.ShowWrappedTable([]string{"Query", "Executions", "Total", "Max", "Rows"}, queryRows, false)
+}
+
+func formatProfileMs(ms float64) string {
+	return (time.Duration(ms * float64(time.Millisecond))).Round(time.Millisecond).String()
+}
+
+// the profile is printed for text and brief output - for other formats, it is included in the JSON output and exports
+func shouldPrintProfile() bool {
+	outputFormat := viper.GetString(constants.ArgOutput)
+
+	return viper.GetBool(constants.ArgProfile) && !viper.GetBool(constants.ArgDryRun) &&
+		(outputFormat == constants.CheckOutputFormatText || outputFormat == constants.CheckOutputFormatBrief)
+}
+
 func shouldPrintTiming() bool {
 	outputFormat := viper.GetString(constants.ArgOutput)
 
@@ -280,6 +385,58 @@ func exportCheckResult(ctx context.Context, d *control.ExportData) {
 	}()
 }
 
+// send the check summary to all notifiers defined in the config
+func notifyCheckResult(ctx context.Context, arg string, executionTree *controlexecute.ExecutionTree) {
+	if viper.GetBool(constants.ArgDryRun) || utils.IsContextCancelled(ctx) {
+		return
+	}
+	notifiers := steampipeconfig.GlobalConfig.NotifierList()
+	if len(notifiers) == 0 {
+		return
+	}
+	alarmState := controlnotify.LoadAlarmState(filepaths.EnsureCheckAlarmStateDir(), arg)
+	for _, err := range controlnotify.NotifyAll(ctx, notifiers, arg, executionTree, alarmState) {
+		utils.ShowWarning(err.Error())
+	}
+	// record the alarms of this run, even if a notifier failed, so they are not raised again as new alarms
+	if err := alarmState.Save(executionTree); err != nil {
+		utils.ShowWarning(fmt.Sprintf("failed to save the alarm state for '%s': %s", arg, err.Error()))
+	}
+}
+
+// persist the check results to the check history store
+func addCheckHistory(ctx context.Context, client db_common.Client, arg string, executionTree *controlexecute.ExecutionTree) {
+	if viper.GetBool(constants.ArgDryRun) || utils.IsContextCancelled(ctx) {
+		return
+	}
+	runId, err := controlhistory.NewStore(client).Add(ctx, arg, executionTree)
+	if err != nil {
+		utils.ShowWarning(fmt.Sprintf("failed to persist check history for '%s': %s", arg, err.Error()))
+		return
+	}
+	log.Printf("[TRACE] persisted check history for '%s' with run id %s", arg, runId)
+}
+
+// load the check results into the check result table
+func loadCheckResultTable(ctx context.Context, client db_common.Client, arg string, executionTree *controlexecute.ExecutionTree, replace bool) {
+	if viper.GetBool(constants.ArgDryRun) || utils.IsContextCancelled(ctx) {
+		return
+	}
+	if err := executionTree.LoadResultTable(ctx, client, arg, replace); err != nil {
+		utils.ShowWarning(fmt.Sprintf("failed to load check results for '%s' into table '%s': %s", arg, constants.CheckResultTable, err.Error()))
+	}
+}
+
+// write the remediation script to the file specified by the '--remediation-script' arg
+func writeRemediationScript(ctx context.Context, script *controlexecute.RemediationScript) {
+	path := viper.GetString(constants.ArgRemediationScript)
+	if err := script.Write(path); err != nil {
+		utils.ShowError(ctx, fmt.Errorf("failed to write remediation script '%s': %s", path, err.Error()))
+		return
+	}
+	log.Printf("[TRACE] wrote %d remediation commands to '%s'", script.CommandCount, path)
+}
+
 func displayControlResults(ctx context.Context, executionTree *controlexecute.ExecutionTree) error {
 	output := viper.GetString(constants.ArgOutput)
 	formatter, _, err := parseOutputArg(output)
@@ -429,3 +586,154 @@ func generateDefaultExportFileName(formatter controldisplay.Formatter, executing
 	timeFormatted := fmt.Sprintf("%d%02d%02d-%02d%02d%02d", now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second())
 	return fmt.Sprintf("%s-%s%s", executing, timeFormatted, formatter.FileExtension())
 }
+
+// merge the JSON exports of multiple (sharded) check runs
+func checkMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge [flags] file1.json file2.json ...",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   runCheckMergeCmd,
+		Short: "Merge check JSON exports into a single report",
+		Long: `Merge the JSON exports of multiple check runs into a single report.
+
+This is typically used to combine the results of runs using the '--shard' flag. Group summaries
+are recalculated from the merged control results. The merged report is written to stdout in JSON format.
+
+Examples:
+
+    # Merge the exports of 2 shards
+    steampipe check merge shard1.json shard2.json > merged.json`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for check merge")
+
+	return cmd
+}
+
+func runCheckMergeCmd(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	utils.LogTime("runCheckMergeCmd start")
+	defer func() {
+		utils.LogTime("runCheckMergeCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(ctx, helpers.ToError(r))
+			exitCode = constants.ExitCodeUnknownErrorPanic
+		}
+	}()
+
+	var roots []*controlexecute.ResultGroup
+	for _, fileName := range args {
+		data, err := os.ReadFile(fileName)
+		utils.FailOnErrorWithMessage(err, fmt.Sprintf("failed to read '%s'", fileName))
+
+		var root controlexecute.ResultGroup
+		err = json.Unmarshal(data, &root)
+		utils.FailOnErrorWithMessage(err, fmt.Sprintf("failed to parse '%s'", fileName))
+		roots = append(roots, &root)
+	}
+
+	merged := controlexecute.MergeResultGroups(roots...)
+	reader, err := controldisplay.JSONFormatter{}.Format(ctx, &controlexecute.ExecutionTree{Root: merged})
+	utils.FailOnError(err)
+	reader, err = prettifyJsonFromReader(reader)
+	utils.FailOnError(err)
+	_, err = io.Copy(os.Stdout, reader)
+	utils.FailOnError(err)
+
+	// set the exit code in the same way as check
+	exitCode = merged.Summary.Status.Alarm + merged.Summary.Status.Error
+}
+
+// show the trend of check results persisted with '--history'
+func checkHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [flags] [benchmark/control]",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   runCheckHistoryCmd,
+		Short: "Show the trend of check results over time",
+		Long: `Show the trend of check results persisted using 'steampipe check --history'.
+
+If a benchmark or control is specified, only the results of that benchmark or control are counted,
+otherwise the summary of each run is shown. Runs are listed oldest first.
+
+Examples:
+
+    # Show the summary of the most recent check runs
+    steampipe check history
+
+    # Show the trend of a benchmark over the last 30 runs
+    steampipe check history benchmark.cis_v140 --limit 30`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, "h", false, "Help for check history").
+		AddIntFlag(constants.ArgLimit, "", 10, "The maximum number of runs to show")
+
+	return cmd
+}
+
+func runCheckHistoryCmd(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	contexthelpers.StartCancelHandler(cancel)
+	utils.LogTime("runCheckHistoryCmd start")
+
+	var client db_common.Client
+	defer func() {
+		utils.LogTime("runCheckHistoryCmd end")
+		if r := recover(); r != nil {
+			utils.ShowError(ctx, helpers.ToError(r))
+			exitCode = constants.ExitCodeUnknownErrorPanic
+		}
+		if client != nil {
+			client.Close(ctx)
+		}
+	}()
+
+	var item string
+	if len(args) > 0 {
+		item = args[0]
+	}
+
+	var err error
+	if connectionString := viper.GetString(constants.ArgConnectionString); connectionString != "" {
+		client, err = db_client.NewDbClient(ctx, connectionString)
+	} else {
+		client, err = db_local.GetLocalClient(ctx, constants.InvokerCheck)
+	}
+	utils.FailOnError(err)
+
+	rows, err := controlhistory.NewStore(client).Trend(ctx, item, viper.GetInt(constants.ArgLimit))
+	utils.FailOnError(err)
+	if len(rows) == 0 {
+		fmt.Println("No check history found")
+		return
+	}
+
+	headers := []string{"Run Start", "Target", "OK", "Alarm", "Info", "Skip", "Error", "Alarm Change", "Score"}
+	var data [][]string
+	for _, row := range rows {
+		alarmChange := ""
+		if row.AlarmChange != nil {
+			alarmChange = fmt.Sprintf("%+d", *row.AlarmChange)
+		}
+		score := ""
+		if row.Score != nil {
+			score = fmt.Sprintf("%.2f%%", *row.Score)
+		}
+		data = append(data, []string{
+			row.StartTime.Local().Format(time.RFC3339),
+			row.Target,
+			fmt.Sprintf("%d", row.Summary.Ok),
+			fmt.Sprintf("%d", row.Summary.Alarm),
+			fmt.Sprintf("%d", row.Summary.Info),
+			fmt.Sprintf("%d", row.Summary.Skip),
+			fmt.Sprintf("%d", row.Summary.Error),
+			alarmChange,
+			score,
+		})
+	}
+	display.ShowWrappedTable(headers, data, false)
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/turbot/steampipe/statushooks"
 	"github.com/turbot/steampipe/workspace"
 
+	"github.com/karrick/gows"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/turbot/go-kit/helpers"
@@ -16,9 +20,14 @@ import (
 	"github.com/turbot/steampipe/cmdconfig"
 	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/contexthelpers"
+	"github.com/turbot/steampipe/control/controldisplay"
 	"github.com/turbot/steampipe/dashboard"
 	"github.com/turbot/steampipe/dashboard/dashboardassets"
+	"github.com/turbot/steampipe/dashboard/dashboarddisplay"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 	"github.com/turbot/steampipe/dashboard/dashboardserver"
+	"github.com/turbot/steampipe/display"
 	"github.com/turbot/steampipe/utils"
 )
 
@@ -31,7 +40,11 @@ func dashboardCmd() *cobra.Command {
 		Short:            "Start the local dashboard UI",
 		Long: `Starts a local web server that enables real-time development of dashboards within the current mod.
 
-The current mod is the working directory, or the directory specified by the --workspace-chdir flag.`,
+The current mod is the working directory, or the directory specified by the --workspace-chdir flag.
+
+To render a dashboard in the console instead, pass the dashboard name and '--output text', e.g.
+
+  steampipe dashboard aws_insights.dashboard.aws_account_report --output text --dashboard-input region=us-east-1`,
 	}
 
 	cmdconfig.OnCmd(cmd).
@@ -39,7 +52,20 @@ The current mod is the working directory, or the directory specified by the --wo
 		AddBoolFlag(constants.ArgModInstall, "", true, "Specify whether to install mod dependencies before running the dashboard").
 		AddStringFlag(constants.ArgDashboardListen, "", string(dashboardserver.ListenTypeLocal), "Accept connections from: local (localhost only) or network (open)").
 		AddIntFlag(constants.ArgDashboardPort, "", constants.DashboardServerDefaultPort, "Dashboard server port.").
+		AddIntFlag(constants.ArgDashboardCacheTTL, "", 0, "The number of seconds for which dashboard results are shared with other sessions viewing the same dashboard with the same inputs (0 to disable)").
+		AddIntFlag(constants.ArgDashboardQueryTimeout, "", 0, "The default number of seconds a dashboard panel query may run for before it is cancelled (0 for no timeout)").
+		AddIntFlag(constants.ArgDashboardMaxParallel, "", constants.DefaultMaxConnections, "The maximum number of queries of a dashboard execution which run concurrently (0 for no limit)").
+		AddIntFlag(constants.ArgDashboardServerMaxParallel, "", 0, "The maximum number of dashboard queries which run concurrently across all executions of the dashboard server (0 for no limit)").
+		AddBoolFlag(constants.ArgDashboardTLS, "", false, "Serve the dashboard over TLS, using the steampipe self-signed certificate unless --dashboard-tls-cert is set").
+		AddStringFlag(constants.ArgDashboardTLSCert, "", "", "Path to a TLS certificate for the dashboard server").
+		AddStringFlag(constants.ArgDashboardTLSKey, "", "", "Path to the private key of the TLS certificate for the dashboard server").
+		AddStringFlag(constants.ArgDashboardAuth, "", string(dashboardserver.AuthTypeNone), "Dashboard authentication: none, basic (STEAMPIPE_DASHBOARD_USERNAME/PASSWORD), token (STEAMPIPE_DASHBOARD_TOKEN) or proxy (trust a reverse proxy user header)").
+		AddStringFlag(constants.ArgDashboardAuthHeader, "", constants.DashboardDefaultAuthHeader, "The user header set by the authenticating reverse proxy when using --dashboard-auth proxy").
 		AddBoolFlag(constants.ArgBrowser, "", true, "Specify whether to launch the browser after starting the dashboard server").
+		AddStringFlag(constants.ArgOutput, "", "", "Render the dashboard in the console rather than starting the dashboard server: text").
+		AddStringFlag(constants.ArgTheme, "", "dark", "Set the output theme for 'text' output: light, dark or plain").
+		// NOTE: use StringArrayFlag for ArgDashboardInput, as input values may contain commas
+		AddStringArrayFlag(constants.ArgDashboardInput, "", nil, "Specify the value of a dashboard input for 'text' output, e.g. region=us-east-1").
 		AddStringSliceFlag(constants.ArgSearchPath, "", nil, "Set a custom search_path for the steampipe user for a check session (comma-separated)").
 		AddStringSliceFlag(constants.ArgSearchPathPrefix, "", nil, "Set a prefix to the current search path for a check session (comma-separated)").
 		AddStringSliceFlag(constants.ArgVarFile, "", nil, "Specify an .spvar file containing variable values").
@@ -61,13 +87,17 @@ func runDashboardCmd(cmd *cobra.Command, args []string) {
 	defer func() {
 		logging.LogTime("runDashboardCmd end")
 		if r := recover(); r != nil {
-			utils.ShowError(dashboardCtx, helpers.ToError(r))
-			if isRunningAsService() {
-				saveErrorToDashboardState(helpers.ToError(r))
-			}
+			handleDashboardCmdPanic(dashboardCtx, r)
 		}
 	}()
 
+	// if an output format was passed, render the dashboard in the console rather than starting the server
+	// NOTE: check the flag was set on the command line - the output terminal option applies to query output
+	if cmd.Flags().Changed(constants.ArgOutput) {
+		renderDashboard(dashboardCtx, args)
+		return
+	}
+
 	serverPort := dashboardserver.ListenPort(viper.GetInt(constants.ArgDashboardPort))
 	utils.FailOnError(serverPort.IsValid())
 
@@ -122,6 +152,121 @@ func runDashboardCmd(cmd *cobra.Command, args []string) {
 	log.Println("[TRACE] runDashboardCmd exiting")
 }
 
+// show the error of a panic of the dashboard command and ensure the command exits with a non-zero exit code
+// (unless a more specific exit code has already been set)
+func handleDashboardCmdPanic(ctx context.Context, r interface{}) {
+	err := helpers.ToError(r)
+	utils.ShowError(ctx, err)
+	if isRunningAsService() {
+		saveErrorToDashboardState(err)
+	}
+	if exitCode == 0 {
+		exitCode = constants.ExitCodeUnknownErrorPanic
+	}
+}
+
+// execute a single dashboard and render the result in the console
+func renderDashboard(ctx context.Context, args []string) {
+	output := viper.GetString(constants.ArgOutput)
+	if output != constants.DashboardOutputFormatText {
+		exitCode = constants.ExitCodeInsufficientOrWrongArguments
+		utils.FailOnError(fmt.Errorf("invalid output format '%s' - supported format: %s", output, constants.DashboardOutputFormatText))
+	}
+	if len(args) != 1 {
+		exitCode = constants.ExitCodeInsufficientOrWrongArguments
+		utils.FailOnError(fmt.Errorf("'--output %s' requires the name of a dashboard or benchmark", output))
+	}
+	dashboardName := args[0]
+
+	ctx, cancel := context.WithCancel(ctx)
+	contexthelpers.StartCancelHandler(cancel)
+
+	// disable all status messages
+	ctx = statushooks.DisableStatusHooks(ctx)
+
+	w, err := loadWorkspacePromptingForVariables(ctx)
+	utils.FailOnErrorWithMessage(err, "failed to load workspace")
+
+	initData := dashboard.NewInitData(ctx, w)
+	// shutdown the service on exit
+	defer initData.Cleanup(ctx)
+
+	err = handleDashboardInitResult(ctx, initData)
+	utils.FailOnError(err)
+
+	utils.FailOnError(controldisplay.InitialiseColorScheme())
+
+	inputs, err := getDashboardInputs(dashboardName, w)
+	if err != nil {
+		exitCode = constants.ExitCodeInsufficientOrWrongArguments
+		utils.FailOnError(err)
+	}
+
+	// execute synchronously - there is no session, so no events are required
+	executionTree, err := dashboardexecute.NewDashboardExecutionTree(dashboardName, "cli", initData.Client, w)
+	utils.FailOnError(err)
+	executionTree.SetInputs(inputs)
+	executionTree.Execute(ctx)
+	if ctx.Err() != nil {
+		utils.FailOnError(ctx.Err())
+	}
+
+	width, _, _ := gows.GetWinSize()
+	renderedText, err := dashboarddisplay.NewTextRenderer(executionTree, width).Render(ctx)
+	utils.FailOnError(err)
+	display.ShowPaged(ctx, renderedText)
+
+	if executionTree.GetRunStatus() == dashboardinterfaces.DashboardRunError {
+		exitCode = constants.ExitCodeUnknownErrorPanic
+	}
+}
+
+// parse the values of the --dashboard-input flag, which have the format <name>=<value>
+// the 'input.' prefix of the input name is optional
+// as there is no UI to select input values, a value must be passed for every input of the dashboard
+func getDashboardInputs(dashboardName string, w *workspace.Workspace) (map[string]interface{}, error) {
+	resourceMaps := w.GetResourceMaps()
+	d, isDashboard := resourceMaps.Dashboards[dashboardName]
+	if _, isBenchmark := resourceMaps.Benchmarks[dashboardName]; !isDashboard && !isBenchmark {
+		return nil, fmt.Errorf("dashboard '%s' does not exist", dashboardName)
+	}
+
+	query := make(url.Values)
+	for _, arg := range viper.GetStringSlice(constants.ArgDashboardInput) {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid dashboard input '%s' - the format is <name>=<value>", arg)
+		}
+		name := parts[0]
+		if !strings.HasPrefix(name, "input.") {
+			name = "input." + name
+		}
+		query.Add(name, parts[1])
+	}
+	inputs := dashboardserver.InputsFromQuery(query, d)
+	if !isDashboard {
+		return inputs, nil
+	}
+
+	var inputErrors, missingInputs []string
+	for name, err := range d.ValidateInputValues(inputs) {
+		inputErrors = append(inputErrors, fmt.Sprintf("%s: %s", name, err.Error()))
+	}
+	if len(inputErrors) > 0 {
+		sort.Strings(inputErrors)
+		return nil, fmt.Errorf("invalid dashboard input values:\n  %s", strings.Join(inputErrors, "\n  "))
+	}
+	for _, input := range d.Inputs {
+		if _, ok := inputs[input.UnqualifiedName]; !ok {
+			missingInputs = append(missingInputs, strings.TrimPrefix(input.UnqualifiedName, "input."))
+		}
+	}
+	if len(missingInputs) > 0 {
+		return nil, fmt.Errorf("dashboard '%s' requires values for inputs: %s - pass them using --%s <name>=<value>", dashboardName, strings.Join(missingInputs, ", "), constants.ArgDashboardInput)
+	}
+	return inputs, nil
+}
+
 // inspect the init result ands
 func handleDashboardInitResult(ctx context.Context, initData *dashboard.InitData) error {
 	// if there is an error or cancellation we bomb out
@@ -155,7 +300,7 @@ func onServerStarted(serverPort dashboardserver.ListenPort, serverListen dashboa
 	} else {
 		// start browser if required
 		if viper.GetBool(constants.ArgBrowser) {
-			if err := dashboardserver.OpenBrowser(fmt.Sprintf("http://localhost:%d", serverPort)); err != nil {
+			if err := dashboardserver.OpenBrowser(dashboardserver.DashboardUrl(int(serverPort), dashboardserver.IsTLSEnabled())); err != nil {
 				log.Println("[TRACE] dashboard server started but failed to start client", err)
 			}
 		}
@@ -190,6 +335,7 @@ func saveDashboardState(serverPort dashboardserver.ListenPort, serverListen dash
 		Port:       int(serverPort),
 		ListenType: string(serverListen),
 		Listen:     constants.DatabaseListenAddresses,
+		TLS:        dashboardserver.IsTLSEnabled(),
 	}
 
 	if serverListen == dashboardserver.ListenTypeNetwork {
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/utils"
+)
+
+type dashboardCmdPanicTest struct {
+	// the exit code set before the panic
+	exitCode int
+	expected int
+}
+
+var testCasesDashboardCmdPanic = map[string]dashboardCmdPanicTest{
+	"render failure": {
+		exitCode: 0,
+		expected: constants.ExitCodeUnknownErrorPanic,
+	},
+	"invalid arguments": {
+		exitCode: constants.ExitCodeInsufficientOrWrongArguments,
+		expected: constants.ExitCodeInsufficientOrWrongArguments,
+	},
+}
+
+func TestHandleDashboardCmdPanic(t *testing.T) {
+	prevExitCode := exitCode
+	defer func() { exitCode = prevExitCode }()
+
+	for name, test := range testCasesDashboardCmdPanic {
+		exitCode = test.exitCode
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					handleDashboardCmdPanic(context.Background(), r)
+				}
+			}()
+			// failures in renderDashboard panic through FailOnError
+			utils.FailOnError(fmt.Errorf("failed to render dashboard"))
+		}()
+		if exitCode != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected exit code %d, got %d", name, test.expected, exitCode)
+		}
+	}
+}
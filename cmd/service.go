@@ -70,6 +70,15 @@ connection from any Postgres compatible database client.`,
 		AddBoolFlag(constants.ArgDashboard, "", false, "Run the dashboard webserver with the service").
 		AddStringFlag(constants.ArgDashboardListen, "", string(dashboardserver.ListenTypeNetwork), "Accept connections from: local (localhost only) or network (open) (dashboard)").
 		AddIntFlag(constants.ArgDashboardPort, "", constants.DashboardServerDefaultPort, "Report server port.").
+		AddIntFlag(constants.ArgDashboardCacheTTL, "", 0, "The number of seconds for which dashboard results are shared with other sessions viewing the same dashboard with the same inputs (0 to disable) (dashboard)").
+		AddIntFlag(constants.ArgDashboardQueryTimeout, "", 0, "The default number of seconds a dashboard panel query may run for before it is cancelled (0 for no timeout) (dashboard)").
+		AddIntFlag(constants.ArgDashboardMaxParallel, "", constants.DefaultMaxConnections, "The maximum number of queries of a dashboard execution which run concurrently (0 for no limit) (dashboard)").
+		AddIntFlag(constants.ArgDashboardServerMaxParallel, "", 0, "The maximum number of dashboard queries which run concurrently across all executions of the dashboard server (0 for no limit) (dashboard)").
+		AddBoolFlag(constants.ArgDashboardTLS, "", false, "Serve the dashboard over TLS, using the steampipe self-signed certificate unless --dashboard-tls-cert is set (dashboard)").
+		AddStringFlag(constants.ArgDashboardTLSCert, "", "", "Path to a TLS certificate for the dashboard server (dashboard)").
+		AddStringFlag(constants.ArgDashboardTLSKey, "", "", "Path to the private key of the TLS certificate for the dashboard server (dashboard)").
+		AddStringFlag(constants.ArgDashboardAuth, "", string(dashboardserver.AuthTypeNone), "Dashboard authentication: none, basic (STEAMPIPE_DASHBOARD_USERNAME/PASSWORD), token (STEAMPIPE_DASHBOARD_TOKEN) or proxy (trust a reverse proxy user header) (dashboard)").
+		AddStringFlag(constants.ArgDashboardAuthHeader, "", constants.DashboardDefaultAuthHeader, "The user header set by the authenticating reverse proxy when using --dashboard-auth proxy (dashboard)").
 		// foreground enables the service to run in the foreground - till exit
 		AddBoolFlag(constants.ArgForeground, "", false, "Run the service in the foreground").
 
@@ -641,7 +650,7 @@ Database:
 	dashboardMsg := ""
 
 	if dashboardState != nil {
-		browserUrl := fmt.Sprintf("http://localhost:%d/", dashboardState.Port)
+		browserUrl := dashboardserver.DashboardUrl(dashboardState.Port, dashboardState.TLS) + "/"
 		dashboardMsg = fmt.Sprintf(`
 Dashboard:
 
@@ -37,6 +37,7 @@ const (
 	ArgDryRun            = "dry-run"
 	ArgWhere             = "where"
 	ArgTag               = "tag"
+	ArgTagFilter         = "tag-filter"
 	ArgVariable          = "var"
 	ArgVarFile           = "var-file"
 	ArgConnectionString  = "connection-string"
@@ -46,6 +47,43 @@ const (
 	ArgServiceMode       = "service-mode"
 	ArgBrowser           = "browser"
 	ArgInput             = "input"
+	ArgCheckpoint        = "checkpoint"
+	ArgShard             = "shard"
+	ArgGroupBy           = "group-by"
+	ArgExitPolicy        = "exit-policy"
+	ArgRemediationScript = "remediation-script"
+	ArgNoResultCache     = "no-result-cache"
+	ArgResultCacheTTL    = "result-cache-ttl"
+	ArgHistory           = "history"
+	ArgLimit             = "limit"
+	ArgResultTable       = "result-table"
+	ArgProfile           = "profile"
+	ArgProfileLimit      = "profile-limit"
+)
+
+// check text output arguments
+const (
+	ArgCheckDisplayColumns    = "check-display-columns"
+	ArgCheckDisplayDimensions = "check-display-dimensions"
+	ArgOnlyFailed             = "only-failed"
+)
+
+// dashboard server arguments
+const (
+	ArgDashboardCacheTTL          = "dashboard-cache-ttl"
+	ArgDashboardTLS               = "dashboard-tls"
+	ArgDashboardTLSCert           = "dashboard-tls-cert"
+	ArgDashboardTLSKey            = "dashboard-tls-key"
+	ArgDashboardAuth              = "dashboard-auth"
+	ArgDashboardAuthHeader        = "dashboard-auth-header"
+	ArgDashboardQueryTimeout      = "dashboard-query-timeout"
+	ArgDashboardMaxParallel       = "dashboard-max-parallel"
+	ArgDashboardServerMaxParallel = "dashboard-server-max-parallel"
+)
+
+// dashboard text output arguments
+const (
+	ArgDashboardInput = "dashboard-input"
 )
 
 /// metaquery mode arguments
@@ -6,4 +6,9 @@ const (
 	// MaxControlRunAttempts determines how many time should a cotnrol run should be retried
 	// in the case of a GRPC connectivity error
 	MaxControlRunAttempts = 2
+	// DefaultResultCacheTTLSecs is the default number of seconds for which cached control results are reused
+	// the result cache is opt-in, so this is zero (disabled)
+	DefaultResultCacheTTLSecs = 0
+	// DefaultProfileLimit is the default number of slowest controls and queries shown by '--profile'
+	DefaultProfileLimit = 10
 )
@@ -9,6 +9,8 @@ import (
 const (
 	DashboardServerDefaultPort    = 9194
 	DashboardAssetsImageRefFormat = "us-docker.pkg.dev/steampipe/steampipe/assets:%s"
+	// DashboardDefaultAuthHeader is the header set by oauth2-proxy and most OIDC reverse proxies
+	DashboardDefaultAuthHeader = "X-Forwarded-User"
 )
 
 var (
@@ -54,6 +54,11 @@ const (
 	CommandCacheOn              = "cache_on"
 	CommandCacheOff             = "cache_off"
 	CommandCacheClear           = "cache_clear"
+
+	// CheckHistorySchema is the schema which stores the results of check runs persisted with '--history'
+	CheckHistorySchema      = "steampipe_check_history"
+	CheckHistoryRunTable    = "run"
+	CheckHistoryResultTable = "control_result"
 )
 
 // Functions :: a list of SQLFunc objects that are installed in the db 'internal' schema startup
@@ -80,6 +85,9 @@ var ReservedConnectionNames = []string{
 	FunctionSchema,
 }
 
+// CheckResultTable is the table in the public schema which check results are loaded into by '--result-table'
+const CheckResultTable = "steampipe_check_result"
+
 // introspection table names
 const (
 	IntrospectionTableQuery              = "steampipe_query"
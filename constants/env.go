@@ -22,4 +22,9 @@ const (
 	EnvInputVarPrefix = "SP_VAR_"
 
 	EnvTelemetry = "STEAMPIPE_TELEMETRY"
+
+	// dashboard server credentials - these are read from the environment so they are not visible in the process list
+	EnvDashboardUsername = "STEAMPIPE_DASHBOARD_USERNAME"
+	EnvDashboardPassword = "STEAMPIPE_DASHBOARD_PASSWORD"
+	EnvDashboardToken    = "STEAMPIPE_DASHBOARD_TOKEN"
 )
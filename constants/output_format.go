@@ -17,4 +17,7 @@ const (
 	CheckOutputFormatMarkdown = "md"
 	CheckOutputFormatNUnit3   = "nunit3"
 	CheckOutputFormatAsffJson = "json-asff"
+
+	// dashboard output format
+	DashboardOutputFormatText = "text"
 )
@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	"github.com/logrusorgru/aurora"
+	"github.com/spf13/viper"
 	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/utils"
 )
@@ -88,6 +89,26 @@ type ControlColorScheme struct {
 	UseColor     bool
 }
 
+// InitialiseColorScheme sets ControlColors to the scheme of the configured theme
+// plain output is enforced for non-terminals
+func InitialiseColorScheme() error {
+	theme := viper.GetString(constants.ArgTheme)
+	if !viper.GetBool(constants.ConfigKeyIsTerminalTTY) {
+		// enforce plain output for non-terminals
+		theme = "plain"
+	}
+	themeDef, ok := ColorSchemes[theme]
+	if !ok {
+		return fmt.Errorf("invalid theme '%s'", theme)
+	}
+	scheme, err := NewControlColorScheme(themeDef)
+	if err != nil {
+		return err
+	}
+	ControlColors = scheme
+	return nil
+}
+
 func NewControlColorScheme(def *ControlColorSchemaDefinition) (*ControlColorScheme, error) {
 	res := &ControlColorScheme{
 		ReasonColors: make(map[string]colorFunc),
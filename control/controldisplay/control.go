@@ -128,5 +128,16 @@ func (r ControlRenderer) Render() string {
 		}
 	}
 
+	// if any resources are in alarm, render the remediation guidance (if any)
+	if r.run.Summary.Alarm > 0 {
+		remediationRenderer := NewRemediationRenderer(r.run.Control.Remediation, r.width, r.resultIndent())
+		if remediationString := remediationRenderer.Render(); remediationString != "" {
+			controlStrings = append(controlStrings,
+				remediationString,
+				// newline after remediation
+				formattedPostResultIndent)
+		}
+	}
+
 	return strings.Join(controlStrings, "\n")
 }
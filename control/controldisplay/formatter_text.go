@@ -17,6 +17,17 @@ const MaxColumns = 200
 type TextFormatter struct{}
 
 func (j *TextFormatter) Format(ctx context.Context, tree *controlexecute.ExecutionTree) (io.Reader, error) {
+	if err := ValidateResultColumns(); err != nil {
+		return nil, err
+	}
+	// if the results have been grouped by a dimension or tag, render each grouping separately
+	if len(tree.Groupings) > 0 {
+		return j.formatGroupings(tree), nil
+	}
+	// if '--only-failed' was passed, only render controls with alarms or errors
+	if viper.GetBool(constants.ArgOnlyFailed) {
+		tree = tree.FailedOnlyCopy()
+	}
 	renderer := NewTableRenderer(tree)
 	widthConstraint := NewRangeConstraint(renderer.MinimumWidth(), MaxColumns)
 	renderedText := renderer.Render(j.getMaxCols(widthConstraint))
@@ -24,15 +35,39 @@ func (j *TextFormatter) Format(ctx context.Context, tree *controlexecute.Executi
 	return res, nil
 }
 
+// render a titled result table (with summary) for each grouping
+func (j *TextFormatter) formatGroupings(tree *controlexecute.ExecutionTree) io.Reader {
+	var groupingStrings []string
+	for _, grouping := range tree.Groupings {
+		groupingTree := &controlexecute.ExecutionTree{
+			Root:                    grouping.Root,
+			DimensionColorGenerator: tree.DimensionColorGenerator,
+		}
+		if viper.GetBool(constants.ArgOnlyFailed) {
+			groupingTree = groupingTree.FailedOnlyCopy()
+		}
+		renderer := NewTableRenderer(groupingTree)
+		widthConstraint := NewRangeConstraint(renderer.MinimumWidth(), MaxColumns)
+		groupingStrings = append(groupingStrings, fmt.Sprintf("%s\n\n%s",
+			ControlColors.GroupTitle(grouping.Title()),
+			renderer.Render(j.getMaxCols(widthConstraint))))
+	}
+	return strings.NewReader(fmt.Sprintf("\n%s\n", strings.Join(groupingStrings, "\n\n")))
+}
+
 func (j *TextFormatter) FileExtension() string {
 	return ".txt"
 }
 
 func (j *TextFormatter) getMaxCols(constraint RangeConstraint) int {
 	colsAvailable, _, _ := gows.GetWinSize()
-	// check if STEAMPIPE_CHECK_DISPLAY_WIDTH env variable is set
-	if viper.IsSet(constants.ArgCheckDisplayWidth) {
-		colsAvailable = viper.GetInt(constants.ArgCheckDisplayWidth)
+	// check if a fixed width was set, using '--check-display-width', the STEAMPIPE_CHECK_DISPLAY_WIDTH env variable
+	// or the terminal options - a fixed width may exceed MaxColumns, e.g. for wide CI logs
+	if width := viper.GetInt(constants.ArgCheckDisplayWidth); width > 0 {
+		if width > constraint.maximum {
+			constraint.maximum = width
+		}
+		colsAvailable = width
 	}
 	return constraint.Constrain(colsAvailable)
 }
@@ -0,0 +1,55 @@
+package controldisplay
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+const remediationLabel = "Remediation: "
+
+type RemediationRenderer struct {
+	remediation *modconfig.ControlRemediation
+	// screen width
+	width  int
+	indent string
+}
+
+func NewRemediationRenderer(remediation *modconfig.ControlRemediation, width int, indent string) *RemediationRenderer {
+	return &RemediationRenderer{
+		remediation: remediation,
+		width:       width,
+		indent:      indent,
+	}
+}
+
+// Render returns the remediation guidance, with each line truncated to the available width
+// if there is no guidance, an empty string is returned
+func (r RemediationRenderer) Render() string {
+	if r.remediation == nil {
+		return ""
+	}
+	guidance := strings.TrimSpace(typehelpers.SafeString(r.remediation.Guidance))
+	if guidance == "" {
+		return ""
+	}
+
+	formattedIndent := fmt.Sprintf("%s", ControlColors.Indent(r.indent))
+	// subsequent lines are aligned with the first line of guidance
+	continuationIndent := strings.Repeat(" ", len(remediationLabel))
+	availableWidth := r.width - helpers.PrintableLength(formattedIndent) - len(remediationLabel)
+
+	var lines []string
+	for i, line := range strings.Split(guidance, "\n") {
+		prefix := continuationIndent
+		if i == 0 {
+			prefix = fmt.Sprintf("%s", ControlColors.StatusInfo(remediationLabel))
+		}
+		line = helpers.TruncateString(strings.TrimRight(line, " \t\r"), availableWidth)
+		lines = append(lines, fmt.Sprintf("%s%s%s", formattedIndent, prefix, ControlColors.ReasonInfo(line)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -2,6 +2,7 @@ package controldisplay
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/viper"
 	"github.com/turbot/go-kit/helpers"
@@ -11,6 +12,15 @@ import (
 
 const minReasonWidth = 10
 
+// the result columns which may be selected using '--check-display-columns'
+const (
+	ResultColumnStatus     = "status"
+	ResultColumnReason     = "reason"
+	ResultColumnDimensions = "dimensions"
+)
+
+var resultColumns = []string{ResultColumnStatus, ResultColumnReason, ResultColumnDimensions}
+
 type ResultRenderer struct {
 	status         string
 	reason         string
@@ -22,18 +32,59 @@ type ResultRenderer struct {
 	// if true, only display failed results
 	errorsOnly bool
 	indent     string
+	// the result columns to display
+	showStatus bool
+	showReason bool
 }
 
 func NewResultRenderer(status, reason string, dimensions []controlexecute.Dimension, colorGenerator *controlexecute.DimensionColorGenerator, width int, indent string) *ResultRenderer {
 	return &ResultRenderer{
 		status:         status,
 		reason:         reason,
-		dimensions:     dimensions,
+		dimensions:     displayDimensions(dimensions),
 		colorGenerator: colorGenerator,
 		width:          width,
 		errorsOnly:     viper.GetString(constants.ArgOutput) == "brief",
 		indent:         indent,
+		showStatus:     showResultColumn(ResultColumnStatus),
+		showReason:     showResultColumn(ResultColumnReason),
+	}
+}
+
+// ValidateResultColumns checks the '--check-display-columns' arg only contains known result columns
+func ValidateResultColumns() error {
+	for _, c := range viper.GetStringSlice(constants.ArgCheckDisplayColumns) {
+		if !helpers.StringSliceContains(resultColumns, c) {
+			return fmt.Errorf("invalid check display column '%s' - must be one of %s", c, strings.Join(resultColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// showResultColumn returns whether the column was selected by '--check-display-columns' (all columns are shown by default)
+func showResultColumn(column string) bool {
+	columns := viper.GetStringSlice(constants.ArgCheckDisplayColumns)
+	return len(columns) == 0 || helpers.StringSliceContains(columns, column)
+}
+
+// displayDimensions returns the dimensions which should be displayed
+// - if the dimensions column is not selected, no dimensions are displayed
+// - if '--check-display-dimensions' is set, only the dimensions with the given keys are displayed
+func displayDimensions(dimensions []controlexecute.Dimension) []controlexecute.Dimension {
+	if !showResultColumn(ResultColumnDimensions) {
+		return nil
+	}
+	keys := viper.GetStringSlice(constants.ArgCheckDisplayDimensions)
+	if len(keys) == 0 {
+		return dimensions
 	}
+	var res []controlexecute.Dimension
+	for _, d := range dimensions {
+		if helpers.StringSliceContains(keys, d.Key) {
+			res = append(res, d)
+		}
+	}
+	return res
 }
 
 func (r ResultRenderer) Render() string {
@@ -42,8 +93,10 @@ func (r ResultRenderer) Render() string {
 		return ""
 	}
 
-	status := NewResultStatusRenderer(r.status)
-	statusString := status.Render()
+	var statusString string
+	if r.showStatus {
+		statusString = NewResultStatusRenderer(r.status).Render()
+	}
 	statusWidth := helpers.PrintableLength(statusString)
 
 	formattedIndent := fmt.Sprintf("%s", ControlColors.Indent(r.indent))
@@ -53,7 +106,10 @@ func (r ResultRenderer) Render() string {
 	availableWidth := r.width - statusWidth - indentWidth
 
 	// for now give this all to reason
-	availableDimensionWidth := availableWidth - minReasonWidth
+	availableDimensionWidth := availableWidth
+	if r.showReason {
+		availableDimensionWidth -= minReasonWidth
+	}
 	var dimensionsString string
 	var dimensionWidth int
 	if availableDimensionWidth > 0 {
@@ -63,7 +119,10 @@ func (r ResultRenderer) Render() string {
 	}
 
 	// now availableWidth is all we have - if it is not enough we need to truncate the reason
-	reasonString := NewResultReasonRenderer(r.status, r.reason, availableWidth).Render()
+	var reasonString string
+	if r.showReason {
+		reasonString = NewResultReasonRenderer(r.status, r.reason, availableWidth).Render()
+	}
 	reasonWidth := helpers.PrintableLength(reasonString)
 
 	// is there any room for a spacer
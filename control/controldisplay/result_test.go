@@ -0,0 +1,81 @@
+package controldisplay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlexecute"
+)
+
+type resultColumnsTest struct {
+	columns    []string
+	dimensions []string
+	expected   []string
+	excluded   []string
+}
+
+func testCasesResultColumns() map[string]resultColumnsTest {
+	return map[string]resultColumnsTest{
+		"all columns": {
+			expected: []string{"ALARM", "bucket is public", "us-east-1", "123456789012"},
+		},
+		"no dimensions": {
+			columns:  []string{ResultColumnStatus, ResultColumnReason},
+			expected: []string{"ALARM", "bucket is public"},
+			excluded: []string{"us-east-1", "123456789012"},
+		},
+		"no status": {
+			columns:  []string{ResultColumnReason, ResultColumnDimensions},
+			expected: []string{"bucket is public", "us-east-1"},
+			excluded: []string{"ALARM"},
+		},
+		"selected dimensions": {
+			dimensions: []string{"region"},
+			expected:   []string{"ALARM", "bucket is public", "us-east-1"},
+			excluded:   []string{"123456789012"},
+		},
+	}
+}
+
+func TestResultColumns(t *testing.T) {
+	themeDef := ColorSchemes["plain"]
+	scheme, _ := NewControlColorScheme(themeDef)
+	ControlColors = scheme
+	defer viper.Set(constants.ArgCheckDisplayColumns, nil)
+	defer viper.Set(constants.ArgCheckDisplayDimensions, nil)
+
+	dimensions := []controlexecute.Dimension{{Key: "region", Value: "us-east-1"}, {Key: "account_id", Value: "123456789012"}}
+	colorGenerator, _ := controlexecute.NewDimensionColorGenerator(4, 27)
+
+	for name, test := range testCasesResultColumns() {
+		viper.Set(constants.ArgCheckDisplayColumns, test.columns)
+		viper.Set(constants.ArgCheckDisplayDimensions, test.dimensions)
+
+		output := NewResultRenderer("alarm", "bucket is public", dimensions, colorGenerator, 100, "").Render()
+		for _, s := range test.expected {
+			if !strings.Contains(output, s) {
+				t.Errorf("Test: '%s'' FAILED : expected output to contain '%s', got:\n %s", name, s, output)
+			}
+		}
+		for _, s := range test.excluded {
+			if strings.Contains(output, s) {
+				t.Errorf("Test: '%s'' FAILED : expected output not to contain '%s', got:\n %s", name, s, output)
+			}
+		}
+	}
+}
+
+func TestValidateResultColumns(t *testing.T) {
+	defer viper.Set(constants.ArgCheckDisplayColumns, nil)
+
+	viper.Set(constants.ArgCheckDisplayColumns, []string{ResultColumnStatus, "resource"})
+	if err := ValidateResultColumns(); err == nil {
+		t.Error("expected error for unknown result column 'resource'")
+	}
+	viper.Set(constants.ArgCheckDisplayColumns, []string{ResultColumnStatus, ResultColumnDimensions})
+	if err := ValidateResultColumns(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
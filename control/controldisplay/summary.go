@@ -72,6 +72,10 @@ func (r SummaryRenderer) Render() string {
 		// summary row
 		summaryRow,
 	)
+	// if any controls were scored, add the score row
+	if scoreRow := NewSummaryScoreRowRenderer(r.resultTree, availableWidth).Render(); scoreRow != "" {
+		summaryLines = append(summaryLines, scoreRow)
+	}
 
 	return strings.Join(summaryLines, "\n")
 }
@@ -0,0 +1,42 @@
+package controldisplay
+
+import (
+	"fmt"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/control/controlexecute"
+)
+
+type SummaryScoreRowRenderer struct {
+	resultTree *controlexecute.ExecutionTree
+	width      int
+}
+
+func NewSummaryScoreRowRenderer(resultTree *controlexecute.ExecutionTree, width int) *SummaryScoreRowRenderer {
+	return &SummaryScoreRowRenderer{
+		resultTree: resultTree,
+		width:      width,
+	}
+}
+
+// Render returns the weighted score row, right aligned to the given width
+// if no controls were scored, an empty string is returned
+func (r *SummaryScoreRowRenderer) Render() string {
+	score := r.resultTree.Root.Summary.Score
+	if score == nil {
+		return ""
+	}
+
+	head := fmt.Sprintf("%s ", ControlColors.GroupTitle("SCORE"))
+	value := fmt.Sprintf("%s", ControlColors.CountTotal(fmt.Sprintf("%.2f%%", score.Percent)))
+
+	spaceWidth := r.width - (helpers.PrintableLength(head) + helpers.PrintableLength(value))
+	spacer := NewSpacerRenderer(spaceWidth)
+
+	return fmt.Sprintf(
+		"%s%s%s",
+		head,
+		spacer.Render(),
+		value,
+	)
+}
@@ -0,0 +1,98 @@
+package controlexecute
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+)
+
+// Checkpoint persists the results of completed control runs to a file,
+// allowing an interrupted check run to be resumed without re-running controls which already succeeded
+// each entry records a hash of the inputs of the run, so results are not reused if the control query,
+// args, search path or connection config have changed
+type Checkpoint struct {
+	// map of control full name to the checkpointed result
+	Controls map[string]*CheckpointEntry `json:"controls"`
+
+	path string
+	lock sync.Mutex
+}
+
+// CheckpointEntry is the persisted result of a single successful control run
+type CheckpointEntry struct {
+	// the hash of the inputs of the run - see ControlRun.getRunHash
+	Hash          string                       `json:"hash,omitempty"`
+	Summary       *controlstatus.StatusSummary `json:"summary"`
+	Rows          []*ResultRow                 `json:"results"`
+	DimensionKeys []string                     `json:"dimension_keys"`
+	Duration      time.Duration                `json:"duration"`
+}
+
+// LoadCheckpoint loads the checkpoint file at the given path
+// if the file does not exist, an empty checkpoint is returned
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{
+		Controls: make(map[string]*CheckpointEntry),
+		path:     path,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return checkpoint, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint file '%s': %s", path, err.Error())
+	}
+	if checkpoint.Controls == nil {
+		checkpoint.Controls = make(map[string]*CheckpointEntry)
+	}
+	log.Printf("[TRACE] loaded checkpoint '%s' containing %d completed controls", path, len(checkpoint.Controls))
+	return checkpoint, nil
+}
+
+// Get returns the checkpointed result for the given control, if one exists and was produced by a run with the given hash
+func (c *Checkpoint) Get(controlName, hash string) (*CheckpointEntry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.Controls[controlName]
+	if !ok || hash == "" || entry.Hash != hash {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Add records the results of a successful control run and writes the checkpoint file
+func (c *Checkpoint) Add(run *ControlRun) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.Controls[run.Control.Name()] = &CheckpointEntry{
+		Hash:          run.runHash,
+		Summary:       run.Summary,
+		Rows:          run.Rows,
+		DimensionKeys: run.DimensionKeys,
+		Duration:      run.Duration,
+	}
+	return c.write()
+}
+
+// write the checkpoint to a temporary file then rename, so an interruption never leaves a truncated file
+func (c *Checkpoint) write() error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmpPath := c.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, c.path)
+}
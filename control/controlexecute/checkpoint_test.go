@@ -0,0 +1,57 @@
+package controlexecute
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	checkpoint, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checkpoint.Controls) != 0 {
+		t.Fatalf("expected empty checkpoint, got %d controls", len(checkpoint.Controls))
+	}
+
+	run := &ControlRun{
+		Control:       &modconfig.Control{FullName: "mod.control.c1"},
+		Summary:       &controlstatus.StatusSummary{Ok: 1, Alarm: 1},
+		Rows:          []*ResultRow{{Status: "ok", Resource: "r1"}, {Status: "alarm", Resource: "r2", Dimensions: []Dimension{{"region", "us-east-1"}}}},
+		DimensionKeys: []string{"region"},
+		runHash:       "hash1",
+	}
+	if err := checkpoint.Add(run); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := reloaded.Get("mod.control.c1", "hash1")
+	if !ok {
+		t.Fatal("expected checkpoint to contain control 'mod.control.c1'")
+	}
+	if *entry.Summary != *run.Summary {
+		t.Errorf("expected summary %v, got %v", *run.Summary, *entry.Summary)
+	}
+	if len(entry.Rows) != 2 || entry.Rows[1].GetDimensionValue("region") != "us-east-1" {
+		t.Errorf("checkpointed rows were not restored correctly: %v", entry.Rows)
+	}
+	if _, ok := reloaded.Get("mod.control.c2", "hash1"); ok {
+		t.Error("expected checkpoint not to contain control 'mod.control.c2'")
+	}
+	// results of a run with different inputs must not be reused
+	if _, ok := reloaded.Get("mod.control.c1", "hash2"); ok {
+		t.Error("expected checkpoint entry with a different hash to be ignored")
+	}
+	if _, ok := reloaded.Get("mod.control.c1", ""); ok {
+		t.Error("expected checkpoint entry to be ignored for a run with no hash")
+	}
+}
@@ -40,6 +40,8 @@ type ControlRun struct {
 	Severity    string            `json:"severity"`
 	Tags        map[string]string `json:"tags"`
 	Title       string            `json:"title"`
+	// the weight of the control when calculating the group score
+	Weight int `json:"weight"`
 
 	// parent result group
 	Group *ResultGroup `json:"-"`
@@ -50,6 +52,12 @@ type ControlRun struct {
 	RunStatus controlstatus.ControlRunStatus `json:"run_status"`
 	// save run error as string for JSON export
 	RunErrorString string `json:"run_error"`
+	// if the control evidence query failed, the error message
+	EvidenceError string `json:"evidence_error,omitempty"`
+	// were the results of this run read from the result cache
+	CacheHit bool `json:"cache_hit,omitempty"`
+	// if profiling is enabled, where the time of this run was spent
+	Profile *ControlRunProfile `json:"profile,omitempty"`
 
 	runError error
 	// the query result stream
@@ -58,6 +66,10 @@ type ControlRun struct {
 	stateLock   sync.Mutex
 	doneChan    chan bool
 	attempts    int
+	// the hash of the inputs of this run, used to validate checkpointed results and as the result cache key
+	runHash string
+	// was this run skipped because its preconditions were not met
+	preconditionSkipped bool
 }
 
 func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree *ExecutionTree) *ControlRun {
@@ -74,6 +86,7 @@ func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree
 		Severity:    typehelpers.SafeString(control.Severity),
 		Title:       typehelpers.SafeString(control.Title),
 		Tags:        control.GetTags(),
+		Weight:      controlWeight(control),
 		rowMap:      make(map[string][]*ResultRow),
 		Summary:     &controlstatus.StatusSummary{},
 		Lifecycle:   utils.NewLifecycleTimer(),
@@ -181,8 +194,40 @@ func (r *ControlRun) execute(ctx context.Context, client db_common.Client) {
 	control := r.Control
 	log.Printf("[TRACE] control start, %s\n", control.Name())
 
+	if r.Tree.checkpoint != nil || r.Tree.resultCache != nil {
+		r.runHash = r.getRunHash(client)
+	}
+
+	// if this control already completed in a previous run, restore the results from the checkpoint
+	if r.Tree.checkpoint != nil {
+		if entry, ok := r.Tree.checkpoint.Get(control.Name(), r.runHash); ok {
+			r.restoreResults(ctx, entry, entry.Duration)
+			return
+		}
+	}
+
 	startTime := time.Now()
 
+	// if the results of an identical run were recently cached, reuse them
+	// this is checked before acquiring a session or checking preconditions, so a cache hit needs no database access
+	// (runs which were skipped because of their preconditions are never cached)
+	if r.Tree.resultCache != nil && r.runHash != "" {
+		if entry, ok := r.Tree.resultCache.Get(r.runHash); ok {
+			log.Printf("[TRACE] using cached results for control %s", control.Name())
+			r.CacheHit = true
+			r.restoreResults(ctx, entry, time.Since(startTime))
+			if r.Tree.profileLimit > 0 {
+				r.Profile = r.buildProfile()
+			}
+			if r.Tree.checkpoint != nil {
+				if err := r.Tree.checkpoint.Add(r); err != nil {
+					log.Printf("[WARN] failed to write checkpoint for control %s: %s", r.Control.Name(), err.Error())
+				}
+			}
+			return
+		}
+	}
+
 	// function to cleanup and update status after control run completion
 	defer func() {
 		// update the result group status with our status - this will be passed all the way up the execution tree
@@ -195,6 +240,22 @@ func (r *ControlRun) execute(ctx context.Context, client db_common.Client) {
 		if r.Group != nil {
 			r.Group.addDuration(r.Duration)
 		}
+		if r.Tree.profileLimit > 0 {
+			r.Profile = r.buildProfile()
+		}
+		// persist successful runs to the checkpoint (if there is one)
+		// runs skipped because of their preconditions are not persisted, as the preconditions may be met next time
+		if r.Tree.checkpoint != nil && r.GetRunStatus() == controlstatus.ControlRunComplete && !r.preconditionSkipped {
+			if err := r.Tree.checkpoint.Add(r); err != nil {
+				log.Printf("[WARN] failed to write checkpoint for control %s: %s", r.Control.Name(), err.Error())
+			}
+		}
+		// cache successful runs (if the result cache is enabled)
+		if r.Tree.resultCache != nil && r.runHash != "" && r.GetRunStatus() == controlstatus.ControlRunComplete && !r.preconditionSkipped {
+			if err := r.Tree.resultCache.Add(r.runHash, r); err != nil {
+				log.Printf("[WARN] failed to cache results for control %s: %s", r.Control.Name(), err.Error())
+			}
+		}
 		log.Printf("[TRACE] finishing with concurrency, %s, , %d\n", r.Control.Name(), r.Tree.Progress.Executing)
 	}()
 
@@ -253,6 +314,19 @@ func (r *ControlRun) execute(ctx context.Context, client db_common.Client) {
 	// prematurely closing the database connection that this query executed in
 	controlExecutionCtx := r.getControlQueryContext(ctx)
 
+	// if the control depends on other controls or has a precondition, check these are met - if not, skip the control
+	r.Lifecycle.Add("precondition_start")
+	skipReason, err := r.checkPreconditions(controlExecutionCtx, client, dbSession)
+	r.Lifecycle.Add("precondition_finish")
+	if err != nil {
+		r.setError(ctx, err)
+		return
+	}
+	if skipReason != "" {
+		r.skipWithReason(ctx, skipReason)
+		return
+	}
+
 	// execute the control query
 	// NOTE no need to pass an OnComplete callback - we are already closing our session after waiting for results
 	log.Printf("[TRACE] execute start for, %s\n", control.Name())
@@ -285,6 +359,77 @@ func (r *ControlRun) execute(ctx context.Context, client db_common.Client) {
 	log.Printf("[TRACE] wait result for, %s\n", control.Name())
 	r.waitForResults(ctx)
 	log.Printf("[TRACE] finish result for, %s\n", control.Name())
+
+	// if the control completed, gather evidence for the results (if the control defines an evidence query)
+	if r.GetRunStatus() == controlstatus.ControlRunComplete {
+		r.gatherEvidence(controlExecutionCtx, client, dbSession)
+	}
+}
+
+// getRunHash returns a hash of the inputs of this run - the resolved control query (which includes the args),
+// the evidence config, the precondition, the controls it depends on, the search path and the connection config
+// if the query cannot be resolved, an empty string is returned - the error is reported when the control executes
+func (r *ControlRun) getRunHash(client db_common.Client) string {
+	query, err := r.resolveControlQuery(r.Control)
+	if err != nil {
+		return ""
+	}
+
+	control := r.Control
+	// copy the session search path, to avoid modifying the client's slice
+	searchPath := append([]string{}, client.GetRequiredSessionSearchPath()...)
+	searchPath = append(searchPath, typehelpers.SafeString(control.SearchPath), typehelpers.SafeString(control.SearchPathPrefix))
+
+	return ResultCacheKey(runHashQuery(query, control), searchPath, connectionConfigMap(client))
+}
+
+// runHashQuery appends the control config which affects the results of the run to the resolved control query
+func runHashQuery(query string, control *modconfig.Control) string {
+	// include the evidence config, as this determines the evidence stored with the results
+	if control.Evidence != nil {
+		query += "\n" + control.Evidence.String()
+	}
+	// include the precondition and dependencies, as these determine whether the control is skipped
+	if control.Precondition != nil {
+		query += fmt.Sprintf("\nprecondition: %s (%s)", typehelpers.SafeString(control.Precondition.SQL), typehelpers.SafeString(control.Precondition.Reason))
+	}
+	if len(control.DependsOn) > 0 {
+		query += "\ndepends_on: " + strings.Join(control.DependsOnNames(), ",")
+	}
+	return query
+}
+
+// populate the run from a stored result (from either the checkpoint or the result cache)
+// and update the execution tree as if the control had executed
+func (r *ControlRun) restoreResults(ctx context.Context, entry *CheckpointEntry, duration time.Duration) {
+	log.Printf("[TRACE] restoring results of control %s", r.Control.Name())
+	r.Lifecycle.Add("results_restored")
+
+	r.Tree.Progress.OnControlStart(ctx, r)
+
+	r.setResults(entry)
+	r.Duration = duration
+
+	r.Group.updateSummary(r.Summary)
+	if len(r.Severity) != 0 {
+		r.Group.updateSeverityCounts(r.Severity, r.Summary)
+	}
+	r.Group.addDuration(r.Duration)
+
+	r.setRunStatus(ctx, controlstatus.ControlRunComplete)
+	r.Tree.Progress.OnControlComplete(ctx, r)
+}
+
+// populate the results of the run from a previously stored result (from either the checkpoint or the result cache)
+func (r *ControlRun) setResults(entry *CheckpointEntry) {
+	r.Summary = entry.Summary
+	r.Rows = entry.Rows
+	r.DimensionKeys = entry.DimensionKeys
+	for _, row := range r.Rows {
+		row.Run = r
+		row.Control = r.Control
+	}
+	r.Group.addDimensionKeys(r.DimensionKeys...)
 }
 
 // try to acquire a database session - retry up to 4 times if there is an error
@@ -395,17 +540,22 @@ func (r *ControlRun) addResultRow(row *ResultRow) {
 	r.rowMap[row.Status] = append(r.rowMap[row.Status], row)
 
 	// update summary
-	switch row.Status {
+	addStatusToSummary(r.Summary, row.Status)
+}
+
+// increment the summary count for the given row status
+func addStatusToSummary(summary *controlstatus.StatusSummary, status string) {
+	switch status {
 	case constants.ControlOk:
-		r.Summary.Ok++
+		summary.Ok++
 	case constants.ControlAlarm:
-		r.Summary.Alarm++
+		summary.Alarm++
 	case constants.ControlSkip:
-		r.Summary.Skip++
+		summary.Skip++
 	case constants.ControlInfo:
-		r.Summary.Info++
+		summary.Info++
 	case constants.ControlError:
-		r.Summary.Error++
+		summary.Error++
 	}
 }
 
@@ -0,0 +1,81 @@
+package controlexecute
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/turbot/go-kit/helpers"
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/query/queryresult"
+)
+
+// is the given column of the control query captured as evidence
+func (r *ControlRun) isEvidenceColumn(column string) bool {
+	evidence := r.Control.Evidence
+	return evidence != nil && helpers.StringSliceContains(evidence.Columns, column)
+}
+
+// if the control defines an evidence query, execute it and add the evidence to the matching result rows
+// a failure to gather evidence does not fail the control run - the error is stored in EvidenceError
+func (r *ControlRun) gatherEvidence(ctx context.Context, client db_common.Client, session *db_common.DatabaseSession) {
+	evidence := r.Control.Evidence
+	if evidence == nil || evidence.SQL == nil || len(r.Rows) == 0 {
+		return
+	}
+	r.Lifecycle.Add("evidence_start")
+	defer r.Lifecycle.Add("evidence_finish")
+
+	result, err := client.ExecuteSyncInSession(ctx, session, typehelpers.SafeString(evidence.SQL))
+	if err == nil {
+		err = r.addEvidence(result)
+	}
+	if err != nil {
+		log.Printf("[WARN] failed to gather evidence for control %s: %s", r.Control.Name(), err.Error())
+		r.EvidenceError = fmt.Sprintf("failed to gather evidence: %s", err.Error())
+	}
+}
+
+// add the rows of the evidence query result to the result rows with the same resource
+// if the evidence query returns multiple rows for a resource, the first is used
+func (r *ControlRun) addEvidence(result *queryresult.SyncQueryResult) error {
+	resourceIdx := -1
+	for i, c := range result.ColTypes {
+		if c.Name() == "resource" {
+			resourceIdx = i
+		}
+	}
+	if resourceIdx == -1 {
+		return fmt.Errorf("evidence query must return a 'resource' column")
+	}
+
+	evidenceMap := make(map[string]map[string]interface{})
+	for _, item := range result.Rows {
+		row, ok := item.(*queryresult.RowResult)
+		if !ok {
+			continue
+		}
+		if row.Error != nil {
+			return row.Error
+		}
+		resource := typehelpers.ToString(row.Data[resourceIdx])
+		if _, ok := evidenceMap[resource]; ok {
+			continue
+		}
+		values := make(map[string]interface{})
+		for i, c := range result.ColTypes {
+			if i != resourceIdx {
+				values[c.Name()] = row.Data[i]
+			}
+		}
+		evidenceMap[resource] = values
+	}
+
+	for _, row := range r.Rows {
+		for k, v := range evidenceMap[row.Resource] {
+			row.AddEvidence(k, v)
+		}
+	}
+	return nil
+}
@@ -7,12 +7,14 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/viper"
 	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/control/controlstatus"
 	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/filepaths"
 	"github.com/turbot/steampipe/query/queryresult"
 	"github.com/turbot/steampipe/statushooks"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
@@ -30,11 +32,32 @@ type ExecutionTree struct {
 	Progress    *controlstatus.ControlProgress `json:"progress"`
 	// map of dimension property name to property value to color map
 	DimensionColorGenerator *DimensionColorGenerator `json:"-"`
+	// if a group-by was specified, a copy of the result tree for each distinct dimension or tag value
+	Groupings []*ResultGrouping `json:"groupings,omitempty"`
+	// if an exit policy was specified, the result of evaluating it against the results
+	PolicyEvaluation *ExitPolicyEvaluation `json:"policy_evaluation,omitempty"`
+	// if profiling is enabled, the slowest controls and queries of the run
+	Profile *ExecutionProfile `json:"profile,omitempty"`
 
 	workspace *workspace.Workspace
 	client    db_common.Client
 	// an optional map of control names used to filter the controls which are run
 	controlNameFilterMap map[string]bool
+	// an optional shard used to select a deterministic subset of the filtered controls
+	shard *Shard
+	// an optional dimension or tag used to break down the results
+	groupBy *GroupBy
+	// an optional policy determining which results cause the run to fail
+	exitPolicy *ExitPolicy
+	// an optional checkpoint used to persist and restore completed control runs
+	checkpoint *Checkpoint
+	// an optional cache used to reuse the results of unchanged controls across check runs
+	resultCache *ResultCache
+	// if profiling is enabled, the number of slowest controls and queries to include in the profile
+	profileLimit int
+	// control runs waiting for the controls they depend on to complete
+	deferredRuns     []*ControlRun
+	deferredRunsLock sync.Mutex
 }
 
 func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, client db_common.Client, arg string) (*ExecutionTree, error) {
@@ -53,6 +76,45 @@ func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, clien
 		return nil, err
 	}
 
+	// if a '--checkpoint' file was passed, load any results it already contains
+	if checkpointPath := viper.GetString(constants.ArgCheckpoint); checkpointPath != "" {
+		executionTree.checkpoint, err = LoadCheckpoint(checkpointPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// if a '--group-by' arg was passed, the results will be regrouped by this dimension or tag after execution
+	if groupByArg := viper.GetString(constants.ArgGroupBy); groupByArg != "" {
+		executionTree.groupBy, err = ParseGroupBy(groupByArg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// if '--exit-policy' args were passed, the policy will be used to determine the failure count after execution
+	if exitPolicyArgs := viper.GetStringSlice(constants.ArgExitPolicy); len(exitPolicyArgs) > 0 {
+		executionTree.exitPolicy, err = ParseExitPolicy(exitPolicyArgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// if a result cache ttl was set (and '--no-result-cache' was not passed), reuse recent results of unchanged controls
+	if ttl := viper.GetInt(constants.ArgResultCacheTTL); ttl > 0 && !viper.GetBool(constants.ArgNoResultCache) {
+		executionTree.resultCache = NewResultCache(filepaths.EnsureCheckResultCacheDir(), time.Duration(ttl)*time.Second)
+		// remove entries which have expired, so the cache directory does not grow indefinitely
+		executionTree.resultCache.Prune()
+	}
+
+	// if '--profile' was passed, record where the time of each control run was spent
+	if viper.GetBool(constants.ArgProfile) {
+		executionTree.profileLimit = viper.GetInt(constants.ArgProfileLimit)
+		if executionTree.profileLimit <= 0 {
+			return nil, fmt.Errorf("'--%s' must be greater than zero", constants.ArgProfileLimit)
+		}
+	}
+
 	// now identify the root item of the control list
 	rootItem, err := executionTree.getExecutionRootFromArg(arg)
 	if err != nil {
@@ -107,12 +169,36 @@ func (e *ExecutionTree) Execute(ctx context.Context) int {
 
 	e.waitForActiveRunsToComplete(ctx, parallelismLock, maxParallelGoRoutines)
 
+	// now execute any control runs which were waiting for their dependencies to complete
+	e.executeDeferredRuns(ctx, parallelismLock, maxParallelGoRoutines)
+
 	failures := e.Root.Summary.Status.Alarm + e.Root.Summary.Status.Error
 
+	// now all controls have completed, calculate the weighted score of each group
+	e.Root.calculateScores()
+
 	// now build map of dimension property name to property value to color map
 	e.DimensionColorGenerator, _ = NewDimensionColorGenerator(4, 27)
 	e.DimensionColorGenerator.populate(e)
 
+	if e.groupBy != nil {
+		e.Groupings = e.buildGroupings(e.groupBy)
+	}
+
+	if e.profileLimit > 0 {
+		e.Profile = e.buildProfile(e.profileLimit)
+	}
+
+	// if there is an exit policy, apply it to determine the failure count
+	if e.exitPolicy != nil {
+		e.PolicyEvaluation = e.exitPolicy.Evaluate(e)
+		log.Printf("[TRACE] exit policy '%s' evaluated: %s", e.PolicyEvaluation.Policy, e.PolicyEvaluation.Reason)
+		failures = 0
+		if e.PolicyEvaluation.Failed {
+			failures = e.PolicyEvaluation.FailureCount
+		}
+	}
+
 	return failures
 }
 
@@ -130,15 +216,66 @@ func (e *ExecutionTree) waitForActiveRunsToComplete(ctx context.Context, paralle
 	parallelismLock.Acquire(waitCtx, maxParallelGoRoutines)
 }
 
+func (e *ExecutionTree) deferRun(run *ControlRun) {
+	e.deferredRunsLock.Lock()
+	defer e.deferredRunsLock.Unlock()
+	e.deferredRuns = append(e.deferredRuns, run)
+}
+
+// execute the deferred control runs in waves - each wave executes the runs whose dependencies have completed
+// NOTE: this must be called once all active runs have completed, i.e. when all semaphores are held
+func (e *ExecutionTree) executeDeferredRuns(ctx context.Context, parallelismLock *semaphore.Weighted, maxParallelGoRoutines int64) {
+	for len(e.deferredRuns) > 0 {
+		runs := e.deferredRuns
+		e.deferredRuns = nil
+
+		if ctx.Err() != nil {
+			for _, run := range runs {
+				run.setError(ctx, ctx.Err())
+			}
+			return
+		}
+
+		// release the semaphores so the deferred runs may execute
+		parallelismLock.Release(maxParallelGoRoutines)
+		for _, run := range runs {
+			executeControlRun(ctx, run, e.client, parallelismLock)
+		}
+		e.waitForActiveRunsToComplete(ctx, parallelismLock, maxParallelGoRoutines)
+
+		// if no deferred run was executed, the remaining runs can never execute
+		// dependency cycles are rejected when the mod is loaded (see Mod.ValidateControlDependencies),
+		// so this is a safeguard against looping forever rather than an expected case
+		if len(e.deferredRuns) == len(runs) {
+			for _, run := range e.deferredRuns {
+				run.setError(ctx, fmt.Errorf("dependencies of %s never completed", run.Control.Name()))
+			}
+			return
+		}
+	}
+}
+
 func (e *ExecutionTree) populateControlFilterMap(ctx context.Context) error {
 	// if both '--where' and '--tag' have been used, then it's an error
 	if viper.IsSet(constants.ArgWhere) && viper.IsSet(constants.ArgTag) {
 		return fmt.Errorf("'--%s' and '--%s' cannot be used together", constants.ArgWhere, constants.ArgTag)
 	}
+	// '--tag-filter' cannot be combined with either
+	if viper.IsSet(constants.ArgTagFilter) && (viper.IsSet(constants.ArgWhere) || viper.IsSet(constants.ArgTag)) {
+		return fmt.Errorf("'--%s' cannot be used with '--%s' or '--%s'", constants.ArgTagFilter, constants.ArgWhere, constants.ArgTag)
+	}
 
 	controlFilterWhereClause := ""
 
-	if viper.IsSet(constants.ArgTag) {
+	if viper.IsSet(constants.ArgTagFilter) {
+		// if a '--tag-filter' expression was used, evaluate it against the tags of each control
+		filter, err := ParseTagFilter(viper.GetString(constants.ArgTagFilter))
+		if err != nil {
+			return err
+		}
+		log.Println("[TRACE]", "filtering controls with tag filter", filter)
+		e.controlNameFilterMap = e.getControlMapFromTagFilter(filter)
+	} else if viper.IsSet(constants.ArgTag) {
 		// if '--tag' args were used, derive the whereClause from them
 		tags := viper.GetStringSlice(constants.ArgTag)
 		controlFilterWhereClause = e.generateWhereClauseFromTags(tags)
@@ -158,6 +295,16 @@ func (e *ExecutionTree) populateControlFilterMap(ctx context.Context) error {
 		}
 	}
 
+	// if a '--shard' arg was used, only the filtered controls allocated to this shard will be run
+	if shardArg := viper.GetString(constants.ArgShard); shardArg != "" {
+		shard, err := ParseShard(shardArg)
+		if err != nil {
+			return err
+		}
+		log.Println("[TRACE]", "running controls for shard", shard)
+		e.shard = shard
+	}
+
 	return nil
 }
 
@@ -190,7 +337,21 @@ func (e *ExecutionTree) generateWhereClauseFromTags(tags []string) string {
 	return strings.Join(whereComponents, " AND ")
 }
 
+// getControlMapFromTagFilter returns a map of the names of all controls whose tags match the filter
+func (e *ExecutionTree) getControlMapFromTagFilter(filter TagFilter) map[string]bool {
+	controlNameMap := make(map[string]bool)
+	for _, control := range e.workspace.GetResourceMaps().Controls {
+		if filter.Matches(control.Tags) {
+			controlNameMap[control.ShortName] = true
+		}
+	}
+	return controlNameMap
+}
+
 func (e *ExecutionTree) ShouldIncludeControl(controlName string) bool {
+	if e.shard != nil && !e.shard.Includes(controlName) {
+		return false
+	}
 	if e.controlNameFilterMap == nil {
 		return true
 	}
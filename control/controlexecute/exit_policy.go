@@ -0,0 +1,160 @@
+package controlexecute
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/utils"
+)
+
+const (
+	exitPolicyKeyStatus         = "status"
+	exitPolicyKeySeverity       = "severity"
+	exitPolicyKeyMaxFailureRate = "max-failure-rate"
+	exitPolicyNone              = "none"
+)
+
+// ExitPolicy determines which control results cause a check run to fail
+type ExitPolicy struct {
+	// if set, the check run never fails
+	Never bool
+	// the result statuses which count as failures (defaults to alarm and error)
+	Statuses []string
+	// if set, only results of controls with these severities count as failures
+	Severities []string
+	// if set, the run only fails if the percentage of failing results exceeds this value
+	MaxFailureRate *float64
+}
+
+// ExitPolicyEvaluation is the result of applying an ExitPolicy to an execution tree
+type ExitPolicyEvaluation struct {
+	Policy       string  `json:"policy"`
+	Failed       bool    `json:"failed"`
+	FailureCount int     `json:"failure_count"`
+	TotalCount   int     `json:"total_count"`
+	FailureRate  float64 `json:"failure_rate"`
+	Reason       string  `json:"reason"`
+}
+
+// ParseExitPolicy parses exit policy args of the form 'key=value', for example:
+// - 'status=alarm' only alarms count as failures
+// - 'severity=critical' only results of critical controls count as failures
+// - 'max-failure-rate=5' fail only if more than 5% of the results are failures
+// - 'none' never fail
+// multiple statuses or severities may be given as comma separated values ('severity=critical,high'),
+// or by repeating the key
+func ParseExitPolicy(args []string) (*ExitPolicy, error) {
+	policy := &ExitPolicy{}
+	for _, arg := range args {
+		arg = strings.TrimSpace(arg)
+		if arg == exitPolicyNone {
+			policy.Never = true
+			continue
+		}
+		values, err := url.ParseQuery(arg)
+		if err != nil || len(values) == 0 {
+			return nil, fmt.Errorf("invalid exit policy '%s'", arg)
+		}
+		for key, vals := range values {
+			for _, v := range strings.Split(strings.Join(vals, ","), ",") {
+				v = strings.ToLower(strings.TrimSpace(v))
+				switch key {
+				case exitPolicyKeyStatus:
+					if !IsValidControlStatus(v) {
+						return nil, fmt.Errorf("invalid exit policy '%s' - '%s' is not a valid control status", arg, v)
+					}
+					policy.Statuses = append(policy.Statuses, v)
+				case exitPolicyKeySeverity:
+					policy.Severities = append(policy.Severities, v)
+				case exitPolicyKeyMaxFailureRate:
+					rate, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64)
+					if err != nil || rate < 0 || rate > 100 {
+						return nil, fmt.Errorf("invalid exit policy '%s' - '%s' must be a percentage between 0 and 100", arg, key)
+					}
+					policy.MaxFailureRate = &rate
+				default:
+					return nil, fmt.Errorf("invalid exit policy '%s' - supported keys are '%s', '%s' and '%s'", arg, exitPolicyKeyStatus, exitPolicyKeySeverity, exitPolicyKeyMaxFailureRate)
+				}
+			}
+		}
+	}
+	if len(policy.Statuses) == 0 {
+		policy.Statuses = []string{constants.ControlAlarm, constants.ControlError}
+	}
+	return policy, nil
+}
+
+// Evaluate applies the policy to the results of the execution tree
+func (p *ExitPolicy) Evaluate(tree *ExecutionTree) *ExitPolicyEvaluation {
+	res := &ExitPolicyEvaluation{Policy: p.String()}
+	for _, run := range tree.ControlRuns {
+		if len(p.Severities) > 0 && !helpers.StringSliceContains(p.Severities, strings.ToLower(run.Severity)) {
+			continue
+		}
+		res.FailureCount += p.failureCount(run.Summary)
+		res.TotalCount += run.Summary.TotalCount()
+	}
+	if res.TotalCount > 0 {
+		res.FailureRate = float64(res.FailureCount) * 100 / float64(res.TotalCount)
+	}
+
+	switch {
+	case p.Never:
+		res.Reason = "exit policy never fails"
+	case res.FailureCount == 0:
+		res.Reason = "no failing results"
+	case p.MaxFailureRate != nil && res.FailureRate <= *p.MaxFailureRate:
+		res.Reason = fmt.Sprintf("failure rate %.2f%% does not exceed %.2f%%", res.FailureRate, *p.MaxFailureRate)
+	case p.MaxFailureRate != nil:
+		res.Failed = true
+		res.Reason = fmt.Sprintf("failure rate %.2f%% exceeds %.2f%%", res.FailureRate, *p.MaxFailureRate)
+	default:
+		res.Failed = true
+		res.Reason = fmt.Sprintf("%d failing %s", res.FailureCount, utils.Pluralize("result", res.FailureCount))
+	}
+	return res
+}
+
+func (p *ExitPolicy) failureCount(summary *controlstatus.StatusSummary) int {
+	count := 0
+	for _, status := range p.Statuses {
+		switch status {
+		case constants.ControlOk:
+			count += summary.Ok
+		case constants.ControlAlarm:
+			count += summary.Alarm
+		case constants.ControlSkip:
+			count += summary.Skip
+		case constants.ControlInfo:
+			count += summary.Info
+		case constants.ControlError:
+			count += summary.Error
+		}
+	}
+	return count
+}
+
+func (p *ExitPolicy) String() string {
+	if p.Never {
+		return exitPolicyNone
+	}
+	var components []string
+	statuses := append([]string{}, p.Statuses...)
+	sort.Strings(statuses)
+	components = append(components, fmt.Sprintf("%s=%s", exitPolicyKeyStatus, strings.Join(statuses, "|")))
+	if len(p.Severities) > 0 {
+		severities := append([]string{}, p.Severities...)
+		sort.Strings(severities)
+		components = append(components, fmt.Sprintf("%s=%s", exitPolicyKeySeverity, strings.Join(severities, "|")))
+	}
+	if p.MaxFailureRate != nil {
+		components = append(components, fmt.Sprintf("%s=%g", exitPolicyKeyMaxFailureRate, *p.MaxFailureRate))
+	}
+	return strings.Join(components, ",")
+}
@@ -0,0 +1,92 @@
+package controlexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+)
+
+type exitPolicyTest struct {
+	args           []string
+	expectedFailed bool
+	expectedCount  int
+}
+
+var exitPolicyTestCases = map[string]exitPolicyTest{
+	"default": {
+		args:           []string{},
+		expectedFailed: true,
+		expectedCount:  4,
+	},
+	"none": {
+		args:           []string{"none"},
+		expectedFailed: false,
+		expectedCount:  4,
+	},
+	"critical only": {
+		args:           []string{"severity=critical"},
+		expectedFailed: true,
+		expectedCount:  1,
+	},
+	"low only": {
+		args:           []string{"severity=low"},
+		expectedFailed: false,
+		expectedCount:  0,
+	},
+	"critical and high": {
+		args:           []string{"severity=critical", "severity=high"},
+		expectedFailed: true,
+		expectedCount:  3,
+	},
+	"critical and high comma separated": {
+		args:           []string{"severity=critical,high"},
+		expectedFailed: true,
+		expectedCount:  3,
+	},
+	"errors only": {
+		args:           []string{"status=error"},
+		expectedFailed: true,
+		expectedCount:  1,
+	},
+	"failure rate not exceeded": {
+		// 4 failures from 9 results
+		args:           []string{"max-failure-rate=45"},
+		expectedFailed: false,
+		expectedCount:  4,
+	},
+	"failure rate exceeded": {
+		args:           []string{"max-failure-rate=44"},
+		expectedFailed: true,
+		expectedCount:  4,
+	},
+}
+
+func TestExitPolicy(t *testing.T) {
+	tree := &ExecutionTree{
+		ControlRuns: []*ControlRun{
+			{Severity: "critical", Summary: &controlstatus.StatusSummary{Alarm: 1, Ok: 2}},
+			{Severity: "high", Summary: &controlstatus.StatusSummary{Alarm: 1, Error: 1, Ok: 1}},
+			{Severity: "", Summary: &controlstatus.StatusSummary{Alarm: 1, Info: 1, Skip: 1}},
+		},
+	}
+
+	for name, test := range exitPolicyTestCases {
+		policy, err := ParseExitPolicy(test.args)
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			continue
+		}
+		evaluation := policy.Evaluate(tree)
+		if evaluation.Failed != test.expectedFailed || evaluation.FailureCount != test.expectedCount {
+			t.Errorf("Test: '%s'' FAILED : expected failed=%v count=%d, got failed=%v count=%d (%s)", name, test.expectedFailed, test.expectedCount, evaluation.Failed, evaluation.FailureCount, evaluation.Reason)
+		}
+	}
+}
+
+func TestParseExitPolicyInvalid(t *testing.T) {
+	for _, arg := range []string{"status=bad", "max-failure-rate=abc", "max-failure-rate=101", "unknown=1"} {
+		if _, err := ParseExitPolicy([]string{arg}); err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected error", arg)
+		}
+	}
+}
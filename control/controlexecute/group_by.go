@@ -0,0 +1,206 @@
+package controlexecute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+)
+
+const (
+	GroupByDimension = "dimension"
+	GroupByTag       = "tag"
+)
+
+// when grouping by dimension, control runs which errored without returning any rows are grouped under this value
+// (runs which completed without returning any rows are grouped with the rows which have no value for the dimension)
+const GroupingValueError = "<error>"
+
+// GroupBy specifies a dimension or tag used to break down the check results
+type GroupBy struct {
+	// either 'dimension' or 'tag'
+	Type string
+	Key  string
+}
+
+// ParseGroupBy parses a group-by argument of the form 'dimension:<key>' or 'tag:<key>'
+func ParseGroupBy(arg string) (*GroupBy, error) {
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 || len(strings.TrimSpace(parts[1])) == 0 {
+		return nil, fmt.Errorf("invalid group-by '%s' - must be of the form 'dimension:<key>' or 'tag:<key>'", arg)
+	}
+	groupBy := &GroupBy{Type: strings.TrimSpace(parts[0]), Key: strings.TrimSpace(parts[1])}
+	if groupBy.Type != GroupByDimension && groupBy.Type != GroupByTag {
+		return nil, fmt.Errorf("invalid group-by '%s' - must be of the form 'dimension:<key>' or 'tag:<key>'", arg)
+	}
+	return groupBy, nil
+}
+
+func (g *GroupBy) String() string {
+	return fmt.Sprintf("%s:%s", g.Type, g.Key)
+}
+
+// ResultGrouping is a copy of the result tree containing only the results for a single dimension or tag value
+type ResultGrouping struct {
+	Type  string       `json:"type"`
+	Key   string       `json:"key"`
+	Value string       `json:"value"`
+	Root  *ResultGroup `json:"root"`
+}
+
+// Title returns the display title of the grouping, e.g. 'account_id: 123456789012'
+func (g *ResultGrouping) Title() string {
+	value := g.Value
+	if value == "" {
+		value = "<none>"
+	}
+	return fmt.Sprintf("%s: %s", g.Key, value)
+}
+
+// buildGroupings regroups the result tree by each distinct value of the group-by dimension or tag
+// each grouping is a filtered copy of the result tree, with summaries recalculated
+func (e *ExecutionTree) buildGroupings(groupBy *GroupBy) []*ResultGrouping {
+	var values []string
+	if groupBy.Type == GroupByDimension {
+		values = e.distinctDimensionValues(groupBy.Key)
+	} else {
+		values = e.distinctTagValues(groupBy.Key)
+	}
+
+	groupings := make([]*ResultGrouping, 0, len(values))
+	for _, value := range values {
+		v := value
+		var filter func(*ControlRun) *ControlRun
+		if groupBy.Type == GroupByDimension {
+			filter = func(run *ControlRun) *ControlRun {
+				// runs with no rows have no dimension values - include them in the error or no value grouping
+				if len(run.Rows) == 0 {
+					if noRowsGroupingValue(run) != v {
+						return nil
+					}
+					return run.copyWithRows(nil)
+				}
+				var rows []*ResultRow
+				for _, row := range run.Rows {
+					if row.GetDimensionValue(groupBy.Key) == v {
+						rows = append(rows, row)
+					}
+				}
+				if len(rows) == 0 {
+					return nil
+				}
+				return run.copyWithRows(rows)
+			}
+		} else {
+			filter = func(run *ControlRun) *ControlRun {
+				if run.Tags[groupBy.Key] != v {
+					return nil
+				}
+				return run.copyWithRows(run.Rows)
+			}
+		}
+
+		root := e.Root.filteredCopy(nil, filter)
+		root.recalculateSummary()
+		groupings = append(groupings, &ResultGrouping{
+			Type:  groupBy.Type,
+			Key:   groupBy.Key,
+			Value: v,
+			Root:  root,
+		})
+	}
+	return groupings
+}
+
+func (e *ExecutionTree) distinctDimensionValues(key string) []string {
+	valueMap := make(map[string]bool)
+	for _, run := range e.ControlRuns {
+		if len(run.Rows) == 0 {
+			valueMap[noRowsGroupingValue(run)] = true
+		}
+		for _, row := range run.Rows {
+			valueMap[row.GetDimensionValue(key)] = true
+		}
+	}
+	return sortedKeys(valueMap)
+}
+
+// noRowsGroupingValue returns the dimension grouping value of a control run which returned no rows
+func noRowsGroupingValue(run *ControlRun) string {
+	if run.runError != nil {
+		return GroupingValueError
+	}
+	return ""
+}
+
+func (e *ExecutionTree) distinctTagValues(key string) []string {
+	valueMap := make(map[string]bool)
+	for _, run := range e.ControlRuns {
+		valueMap[run.Tags[key]] = true
+	}
+	return sortedKeys(valueMap)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// filteredCopy returns a copy of the group, containing the control runs returned by the filter function
+// groups which contain no control runs after filtering are omitted
+func (r *ResultGroup) filteredCopy(parent *ResultGroup, filter func(*ControlRun) *ControlRun) *ResultGroup {
+	res := newResultGroupFrom(r, parent)
+	res.GroupItem = r.GroupItem
+	res.DimensionKeys = r.DimensionKeys
+	res.Duration = r.Duration
+
+	for _, run := range r.ControlRuns {
+		if filteredRun := filter(run); filteredRun != nil {
+			filteredRun.Group = res
+			res.ControlRuns = append(res.ControlRuns, filteredRun)
+		}
+	}
+	for _, child := range r.Groups {
+		if filteredChild := child.filteredCopy(res, filter); filteredChild.ControlRunCount() > 0 {
+			res.Groups = append(res.Groups, filteredChild)
+		}
+	}
+	return res
+}
+
+// copyWithRows returns a copy of the control run containing the given rows, with the summary recalculated
+func (r *ControlRun) copyWithRows(rows []*ResultRow) *ControlRun {
+	res := &ControlRun{
+		Control:        r.Control,
+		Summary:        &controlstatus.StatusSummary{},
+		Rows:           rows,
+		DimensionKeys:  r.DimensionKeys,
+		Duration:       r.Duration,
+		ControlId:      r.ControlId,
+		Description:    r.Description,
+		Severity:       r.Severity,
+		Tags:           r.Tags,
+		Title:          r.Title,
+		Weight:         r.Weight,
+		Tree:           r.Tree,
+		Lifecycle:      r.Lifecycle,
+		RunStatus:      r.RunStatus,
+		RunErrorString: r.RunErrorString,
+		EvidenceError:  r.EvidenceError,
+		CacheHit:       r.CacheHit,
+		Profile:        r.Profile,
+		runError:       r.runError,
+	}
+	if r.runError != nil {
+		res.Summary.Error = r.Summary.Error
+	}
+	for _, row := range rows {
+		addStatusToSummary(res.Summary, row.Status)
+	}
+	return res
+}
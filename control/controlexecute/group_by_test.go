@@ -0,0 +1,93 @@
+package controlexecute
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	validArgs := map[string]GroupBy{
+		"dimension:account_id": {Type: GroupByDimension, Key: "account_id"},
+		"tag:service":          {Type: GroupByTag, Key: "service"},
+	}
+	for arg, expected := range validArgs {
+		groupBy, err := ParseGroupBy(arg)
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", arg, err)
+			continue
+		}
+		if *groupBy != expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", arg, expected, *groupBy)
+		}
+	}
+	for _, arg := range []string{"account_id", "dimension:", "column:account_id"} {
+		if _, err := ParseGroupBy(arg); err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected error", arg)
+		}
+	}
+}
+
+func TestBuildGroupingsByDimension(t *testing.T) {
+	rows := []*ResultRow{
+		{Status: "alarm", Dimensions: []Dimension{{"account_id", "111"}}},
+		{Status: "ok", Dimensions: []Dimension{{"account_id", "111"}}},
+		{Status: "ok", Dimensions: []Dimension{{"account_id", "222"}}},
+	}
+	run := &ControlRun{ControlId: "control.c1", Severity: "high", Rows: rows, Summary: &controlstatus.StatusSummary{Alarm: 1, Ok: 2}}
+	benchmark := &ResultGroup{GroupId: "benchmark.b1", ControlRuns: []*ControlRun{run}}
+	tree := &ExecutionTree{
+		Root:        &ResultGroup{GroupId: RootResultGroupName, Groups: []*ResultGroup{benchmark}},
+		ControlRuns: []*ControlRun{run},
+	}
+
+	groupings := tree.buildGroupings(&GroupBy{Type: GroupByDimension, Key: "account_id"})
+	if len(groupings) != 2 {
+		t.Fatalf("expected 2 groupings, got %d", len(groupings))
+	}
+	expected := map[string]controlstatus.StatusSummary{
+		"111": {Alarm: 1, Ok: 1},
+		"222": {Ok: 1},
+	}
+	for _, grouping := range groupings {
+		if grouping.Root.Summary.Status != expected[grouping.Value] {
+			t.Errorf("grouping '%s': expected summary %v, got %v", grouping.Value, expected[grouping.Value], grouping.Root.Summary.Status)
+		}
+		if grouping.Root.Summary.Severity["high"] != expected[grouping.Value] {
+			t.Errorf("grouping '%s': expected severity summary %v, got %v", grouping.Value, expected[grouping.Value], grouping.Root.Summary.Severity["high"])
+		}
+	}
+}
+
+func TestBuildGroupingsByDimensionIncludesRunsWithNoRows(t *testing.T) {
+	rows := []*ResultRow{
+		{Status: "ok", Dimensions: []Dimension{{"account_id", "111"}}},
+	}
+	run := &ControlRun{ControlId: "control.c1", Rows: rows, Summary: &controlstatus.StatusSummary{Ok: 1}}
+	errorRun := &ControlRun{ControlId: "control.c2", Summary: &controlstatus.StatusSummary{Error: 1}, runError: fmt.Errorf("query failed")}
+	emptyRun := &ControlRun{ControlId: "control.c3", Summary: &controlstatus.StatusSummary{}}
+	benchmark := &ResultGroup{GroupId: "benchmark.b1", ControlRuns: []*ControlRun{run, errorRun, emptyRun}}
+	tree := &ExecutionTree{
+		Root:        &ResultGroup{GroupId: RootResultGroupName, Groups: []*ResultGroup{benchmark}},
+		ControlRuns: []*ControlRun{run, errorRun, emptyRun},
+	}
+
+	groupings := tree.buildGroupings(&GroupBy{Type: GroupByDimension, Key: "account_id"})
+	expected := map[string]controlstatus.StatusSummary{
+		"111":              {Ok: 1},
+		"":                 {},
+		GroupingValueError: {Error: 1},
+	}
+	if len(groupings) != len(expected) {
+		t.Fatalf("expected %d groupings, got %d", len(expected), len(groupings))
+	}
+	for _, grouping := range groupings {
+		if grouping.Root.ControlRunCount() != 1 {
+			t.Errorf("grouping '%s': expected 1 control run, got %d", grouping.Value, grouping.Root.ControlRunCount())
+		}
+		if grouping.Root.Summary.Status != expected[grouping.Value] {
+			t.Errorf("grouping '%s': expected summary %v, got %v", grouping.Value, expected[grouping.Value], grouping.Root.Summary.Status)
+		}
+	}
+}
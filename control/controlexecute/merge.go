@@ -0,0 +1,102 @@
+package controlexecute
+
+import (
+	"sync"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+)
+
+// MergeResultGroups combines the result trees from multiple check runs (e.g. the JSON exports of sharded runs)
+// into a single tree. Groups are matched by group id and control runs by control id - if the same control
+// appears in more than one tree, the first occurrence is used.
+// The summaries of all groups in the merged tree are recalculated from the control runs they contain.
+func MergeResultGroups(groups ...*ResultGroup) *ResultGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	merged := newResultGroupFrom(groups[0], nil)
+	for _, g := range groups {
+		merged.mergeFrom(g)
+	}
+	merged.recalculateSummary()
+	return merged
+}
+
+// create an empty group with the same properties as the source group
+func newResultGroupFrom(source *ResultGroup, parent *ResultGroup) *ResultGroup {
+	return &ResultGroup{
+		GroupId:     source.GroupId,
+		Title:       source.Title,
+		Description: source.Description,
+		Tags:        source.Tags,
+		Type:        source.Type,
+		Display:     source.Display,
+		Parent:      parent,
+		Groups:      []*ResultGroup{},
+		Summary:     NewGroupSummary(),
+		Severity:    make(map[string]controlstatus.StatusSummary),
+		updateLock:  new(sync.Mutex),
+	}
+}
+
+func (r *ResultGroup) mergeFrom(source *ResultGroup) {
+	for _, run := range source.ControlRuns {
+		if r.getControlRunById(run.ControlId) != nil {
+			continue
+		}
+		run.Group = r
+		r.ControlRuns = append(r.ControlRuns, run)
+	}
+	for _, sourceChild := range source.Groups {
+		child := r.GetGroupByName(sourceChild.GroupId)
+		if child == nil {
+			child = newResultGroupFrom(sourceChild, r)
+			r.Groups = append(r.Groups, child)
+		}
+		child.mergeFrom(sourceChild)
+	}
+}
+
+func (r *ResultGroup) getControlRunById(controlId string) *ControlRun {
+	for _, run := range r.ControlRuns {
+		if run.ControlId == controlId {
+			return run
+		}
+	}
+	return nil
+}
+
+// rebuild the status and severity summaries of this group (and all descendants) from the control runs
+func (r *ResultGroup) recalculateSummary() {
+	r.Summary = NewGroupSummary()
+	r.Severity = make(map[string]controlstatus.StatusSummary)
+
+	for _, run := range r.ControlRuns {
+		if run.Summary == nil {
+			continue
+		}
+		r.addToSummary(run.Severity, *run.Summary)
+	}
+	for _, child := range r.Groups {
+		child.recalculateSummary()
+		r.Summary.Status.Merge(&child.Summary.Status)
+		for severity, summary := range child.Summary.Severity {
+			r.addSeveritySummary(severity, summary)
+		}
+	}
+	r.updateScore()
+}
+
+func (r *ResultGroup) addToSummary(severity string, summary controlstatus.StatusSummary) {
+	r.Summary.Status.Merge(&summary)
+	if len(severity) != 0 {
+		r.addSeveritySummary(severity, summary)
+	}
+}
+
+func (r *ResultGroup) addSeveritySummary(severity string, summary controlstatus.StatusSummary) {
+	val := r.Summary.Severity[severity]
+	val.Merge(&summary)
+	r.Summary.Severity[severity] = val
+	r.Severity[severity] = val
+}
@@ -0,0 +1,66 @@
+package controlexecute
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const shard1Json = `{
+	"group_id": "root_result_group",
+	"summary": {"status": {"alarm": 1, "ok": 1}},
+	"groups": [{
+		"group_id": "benchmark.b1",
+		"summary": {"status": {"alarm": 1, "ok": 1}},
+		"groups": [],
+		"controls": [{"control_id": "control.c1", "severity": "high", "summary": {"alarm": 1, "ok": 1}}]
+	}],
+	"controls": null
+}`
+
+const shard2Json = `{
+	"group_id": "root_result_group",
+	"summary": {"status": {"ok": 2, "error": 1}},
+	"groups": [{
+		"group_id": "benchmark.b1",
+		"summary": {"status": {"ok": 2}},
+		"groups": [],
+		"controls": [{"control_id": "control.c2", "summary": {"ok": 2}}]
+	}, {
+		"group_id": "benchmark.b2",
+		"summary": {"status": {"error": 1}},
+		"groups": [],
+		"controls": [{"control_id": "control.c3", "severity": "high", "summary": {"error": 1}}]
+	}],
+	"controls": null
+}`
+
+func TestMergeResultGroups(t *testing.T) {
+	var roots []*ResultGroup
+	for _, data := range []string{shard1Json, shard2Json} {
+		var root ResultGroup
+		if err := json.Unmarshal([]byte(data), &root); err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, &root)
+	}
+
+	merged := MergeResultGroups(roots...)
+
+	if len(merged.Groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(merged.Groups))
+	}
+	b1 := merged.GetGroupByName("benchmark.b1")
+	if len(b1.ControlRuns) != 2 {
+		t.Errorf("expected benchmark.b1 to contain 2 controls, got %d", len(b1.ControlRuns))
+	}
+	if b1.Summary.Status.Ok != 3 || b1.Summary.Status.Alarm != 1 {
+		t.Errorf("unexpected benchmark.b1 summary %v", b1.Summary.Status)
+	}
+	status := merged.Summary.Status
+	if status.Ok != 3 || status.Alarm != 1 || status.Error != 1 {
+		t.Errorf("unexpected root summary %v", status)
+	}
+	if high := merged.Summary.Severity["high"]; high.Alarm != 1 || high.Error != 1 || high.Ok != 1 {
+		t.Errorf("unexpected root 'high' severity summary %v", high)
+	}
+}
@@ -0,0 +1,37 @@
+package controlexecute
+
+// FailedOnlyCopy returns a copy of the execution tree containing only the control runs with alarms or errors
+// groups containing no failed control runs are omitted
+// the summaries of the copied groups are those of the source groups, so they still reflect all results
+func (e *ExecutionTree) FailedOnlyCopy() *ExecutionTree {
+	root := e.Root.filteredCopy(nil, func(run *ControlRun) *ControlRun {
+		if run.Summary.FailedCount() == 0 {
+			return nil
+		}
+		return run.copyWithRows(run.Rows)
+	})
+	root.copySummaries(e.Root)
+
+	return &ExecutionTree{
+		Root:                    root,
+		StartTime:               e.StartTime,
+		EndTime:                 e.EndTime,
+		DimensionColorGenerator: e.DimensionColorGenerator,
+		PolicyEvaluation:        e.PolicyEvaluation,
+		Profile:                 e.Profile,
+	}
+}
+
+// copySummaries sets the summary of this group and all descendant groups to the summary of the corresponding source group
+func (r *ResultGroup) copySummaries(source *ResultGroup) {
+	r.Summary = source.Summary
+	r.Severity = source.Severity
+	for _, child := range r.Groups {
+		for _, sourceChild := range source.Groups {
+			if sourceChild.GroupId == child.GroupId {
+				child.copySummaries(sourceChild)
+				break
+			}
+		}
+	}
+}
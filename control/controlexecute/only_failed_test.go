@@ -0,0 +1,48 @@
+package controlexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestFailedOnlyCopy(t *testing.T) {
+	root := &ResultGroup{GroupId: RootResultGroupName, Summary: NewGroupSummary()}
+	passing := &ResultGroup{GroupId: "benchmark.passing", Parent: root, Summary: NewGroupSummary()}
+	failing := &ResultGroup{GroupId: "benchmark.failing", Parent: root, Summary: NewGroupSummary()}
+	root.Groups = []*ResultGroup{passing, failing}
+
+	newRun := func(name string, group *ResultGroup, summary controlstatus.StatusSummary) *ControlRun {
+		run := &ControlRun{Control: &modconfig.Control{FullName: name}, ControlId: name, Group: group, Summary: &summary}
+		for i := 0; i < summary.Alarm; i++ {
+			run.Rows = append(run.Rows, &ResultRow{Status: "alarm"})
+		}
+		for i := 0; i < summary.Ok; i++ {
+			run.Rows = append(run.Rows, &ResultRow{Status: "ok"})
+		}
+		group.ControlRuns = append(group.ControlRuns, run)
+		return run
+	}
+	newRun("control.ok1", passing, controlstatus.StatusSummary{Ok: 2})
+	newRun("control.ok2", failing, controlstatus.StatusSummary{Ok: 1})
+	failedRun := newRun("control.alarm", failing, controlstatus.StatusSummary{Ok: 1, Alarm: 1})
+	root.recalculateSummary()
+
+	tree := &ExecutionTree{Root: root}
+	res := tree.FailedOnlyCopy()
+
+	if len(res.Root.Groups) != 1 || res.Root.Groups[0].GroupId != "benchmark.failing" {
+		t.Fatalf("expected only the failing group to be copied, got %v", res.Root.Groups)
+	}
+	runs := res.Root.Groups[0].ControlRuns
+	if len(runs) != 1 || runs[0].ControlId != "control.alarm" {
+		t.Fatalf("expected only the failed control run to be copied, got %v", runs)
+	}
+	if runs[0] == failedRun || failedRun.Group != failing {
+		t.Error("expected the source control run to be unchanged")
+	}
+	if res.Root.Summary.Status.TotalCount() != 5 || res.Root.Groups[0].Summary.Status.TotalCount() != 3 {
+		t.Errorf("expected the copied summaries to include all results, got %v and %v", res.Root.Summary.Status, res.Root.Groups[0].Summary.Status)
+	}
+}
@@ -0,0 +1,147 @@
+package controlexecute
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// hasPendingDependencies returns whether any of the controls this run depends on
+// are part of the execution tree and have not yet finished
+func (r *ControlRun) hasPendingDependencies() bool {
+	for _, dep := range r.Control.DependsOn {
+		if depRun := r.Tree.getControlRun(dep.Name()); depRun != nil && !depRun.Finished() {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPreconditions verifies the controls this control depends on have passed, and the precondition query (if any)
+// returns true. If not, the reason the control should be skipped is returned
+func (r *ControlRun) checkPreconditions(ctx context.Context, client db_common.Client, session *db_common.DatabaseSession) (string, error) {
+	for _, dep := range r.Control.DependsOn {
+		passed, err := r.dependencyPassed(ctx, dep, client, session)
+		if err != nil {
+			return "", err
+		}
+		if !passed {
+			return fmt.Sprintf("Skipped as dependency %s did not pass", dep.Name()), nil
+		}
+	}
+
+	precondition := r.Control.Precondition
+	if precondition == nil || precondition.SQL == nil {
+		return "", nil
+	}
+	result, err := client.ExecuteSyncInSession(ctx, session, typehelpers.SafeString(precondition.SQL))
+	if err != nil {
+		return "", fmt.Errorf("precondition query failed: %s", err.Error())
+	}
+	met, err := preconditionResult(result)
+	if err != nil {
+		return "", err
+	}
+	if met {
+		return "", nil
+	}
+	if precondition.Reason != nil {
+		return *precondition.Reason, nil
+	}
+	return "Skipped as precondition was not met", nil
+}
+
+// dependencyPassed returns whether the given dependency control has no alarms or errors
+// if the dependency is part of the execution tree, its results are used, otherwise its query is executed
+func (r *ControlRun) dependencyPassed(ctx context.Context, dep *modconfig.Control, client db_common.Client, session *db_common.DatabaseSession) (bool, error) {
+	if depRun := r.Tree.getControlRun(dep.Name()); depRun != nil {
+		summary := depRun.GetStatusSummary()
+		return depRun.GetError() == nil && !depRun.preconditionSkipped && summary.Alarm == 0 && summary.Error == 0, nil
+	}
+
+	resolvedQuery, err := r.Tree.workspace.ResolveQueryFromQueryProvider(dep, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve query for dependency %s: %s", dep.Name(), err.Error())
+	}
+	result, err := client.ExecuteSyncInSession(ctx, session, resolvedQuery.ExecuteSQL)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute dependency %s: %s", dep.Name(), err.Error())
+	}
+
+	statusIdx := -1
+	for i, c := range result.ColTypes {
+		if c.Name() == "status" {
+			statusIdx = i
+		}
+	}
+	if statusIdx == -1 {
+		return false, fmt.Errorf("dependency %s result is missing required column 'status'", dep.Name())
+	}
+	for _, item := range result.Rows {
+		row, ok := item.(*queryresult.RowResult)
+		if !ok {
+			continue
+		}
+		if row.Error != nil {
+			return false, nil
+		}
+		if status := typehelpers.ToString(row.Data[statusIdx]); status == constants.ControlAlarm || status == constants.ControlError {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// the precondition query must return a single boolean value
+func preconditionResult(result *queryresult.SyncQueryResult) (bool, error) {
+	if len(result.Rows) != 1 || len(result.ColTypes) != 1 {
+		return false, fmt.Errorf("precondition query must return a single boolean value")
+	}
+	row, ok := result.Rows[0].(*queryresult.RowResult)
+	if !ok {
+		return false, fmt.Errorf("precondition query must return a single boolean value")
+	}
+	if row.Error != nil {
+		return false, fmt.Errorf("precondition query failed: %s", row.Error.Error())
+	}
+	switch v := row.Data[0].(type) {
+	case bool:
+		return v, nil
+	case string:
+		if met, err := strconv.ParseBool(v); err == nil {
+			return met, nil
+		}
+	}
+	return false, fmt.Errorf("precondition query must return a single boolean value")
+}
+
+// skipWithReason sets the results of the run to a single skip row with the given reason
+func (r *ControlRun) skipWithReason(ctx context.Context, reason string) {
+	r.preconditionSkipped = true
+	r.Rows = []*ResultRow{{
+		Reason:   reason,
+		Resource: r.Control.Name(),
+		Status:   constants.ControlSkip,
+		Run:      r,
+		Control:  r.Control,
+	}}
+	addStatusToSummary(r.Summary, constants.ControlSkip)
+	r.setRunStatus(ctx, controlstatus.ControlRunComplete)
+}
+
+// getControlRun returns the run for the control with the given name, if it is part of the execution tree
+func (e *ExecutionTree) getControlRun(name string) *ControlRun {
+	for _, run := range e.ControlRuns {
+		if run.Control.Name() == name {
+			return run
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package controlexecute
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/query/queryresult"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestHasPendingDependencies(t *testing.T) {
+	c1 := &modconfig.Control{FullName: "m1.control.c1"}
+	c2 := &modconfig.Control{FullName: "m1.control.c2", DependsOn: []*modconfig.Control{c1}}
+	// c3 depends on a control which is not in the execution tree
+	c3 := &modconfig.Control{FullName: "m1.control.c3", DependsOn: []*modconfig.Control{{FullName: "m1.control.other"}}}
+
+	tree := &ExecutionTree{}
+	run1 := &ControlRun{Control: c1, Tree: tree, RunStatus: controlstatus.ControlRunReady}
+	run2 := &ControlRun{Control: c2, Tree: tree}
+	run3 := &ControlRun{Control: c3, Tree: tree}
+	tree.ControlRuns = []*ControlRun{run1, run2, run3}
+
+	if !run2.hasPendingDependencies() {
+		t.Error("expected c2 to have pending dependencies while c1 has not finished")
+	}
+	if run3.hasPendingDependencies() {
+		t.Error("expected c3 not to have pending dependencies, as its dependency is not in the execution tree")
+	}
+
+	run1.RunStatus = controlstatus.ControlRunComplete
+	if run2.hasPendingDependencies() {
+		t.Error("expected c2 not to have pending dependencies once c1 has finished")
+	}
+}
+
+type preconditionResultTest struct {
+	rows     []interface{}
+	expected interface{}
+}
+
+var testCasesPreconditionResult = map[string]preconditionResultTest{
+	"true": {
+		rows:     []interface{}{&queryresult.RowResult{Data: []interface{}{true}}},
+		expected: true,
+	},
+	"false": {
+		rows:     []interface{}{&queryresult.RowResult{Data: []interface{}{false}}},
+		expected: false,
+	},
+	"string": {
+		rows:     []interface{}{&queryresult.RowResult{Data: []interface{}{"true"}}},
+		expected: true,
+	},
+	"no rows": {
+		rows:     nil,
+		expected: "ERROR",
+	},
+	"multiple rows": {
+		rows:     []interface{}{&queryresult.RowResult{Data: []interface{}{true}}, &queryresult.RowResult{Data: []interface{}{true}}},
+		expected: "ERROR",
+	},
+	"not boolean": {
+		rows:     []interface{}{&queryresult.RowResult{Data: []interface{}{"enabled"}}},
+		expected: "ERROR",
+	},
+}
+
+func TestPreconditionResult(t *testing.T) {
+	for name, test := range testCasesPreconditionResult {
+		result := &queryresult.SyncQueryResult{Rows: test.rows, ColTypes: []*sql.ColumnType{nil}}
+		met, err := preconditionResult(result)
+		if err != nil {
+			if test.expected != "ERROR" {
+				t.Errorf("Test: '%s'' FAILED with unexpected error: %v", name, err)
+			}
+			continue
+		}
+		if test.expected == "ERROR" {
+			t.Errorf("Test: '%s'' FAILED - expected error", name)
+			continue
+		}
+		if met != test.expected.(bool) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, met)
+		}
+	}
+}
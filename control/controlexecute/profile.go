@@ -0,0 +1,107 @@
+package controlexecute
+
+import (
+	"sort"
+	"time"
+)
+
+// ControlRunProfile records where the time of a single control run was spent
+type ControlRunProfile struct {
+	// total wall time of the run
+	DurationMs float64 `json:"duration_ms"`
+	// time spent waiting for a database session
+	QueueMs float64 `json:"queue_ms"`
+	// time spent executing the control query and streaming its results
+	QueryMs float64 `json:"query_ms"`
+	// the number of rows returned by the control query
+	RowCount int `json:"row_count"`
+}
+
+// ControlProfileEntry is the profile of a single control run, as listed in the execution profile
+type ControlProfileEntry struct {
+	ControlId string `json:"control_id"`
+	CacheHit  bool   `json:"cache_hit,omitempty"`
+	*ControlRunProfile
+}
+
+// QueryProfileEntry is the combined profile of all control runs which executed the same query
+type QueryProfileEntry struct {
+	// the name of the query - for controls with inline sql, this is the control name
+	Query        string  `json:"query"`
+	Executions   int     `json:"executions"`
+	TotalQueryMs float64 `json:"total_query_ms"`
+	MaxQueryMs   float64 `json:"max_query_ms"`
+	RowCount     int     `json:"row_count"`
+}
+
+// ExecutionProfile lists the slowest controls and queries of a check run
+type ExecutionProfile struct {
+	SlowestControls []*ControlProfileEntry `json:"slowest_controls"`
+	SlowestQueries  []*QueryProfileEntry   `json:"slowest_queries"`
+}
+
+// buildProfile records the profile of the control run from its lifecycle events
+func (r *ControlRun) buildProfile() *ControlRunProfile {
+	return &ControlRunProfile{
+		DurationMs: durationMs(r.Duration),
+		QueueMs:    durationMs(r.Lifecycle.GetDurationBetween("queued_for_session", "got_session")),
+		QueryMs:    durationMs(r.Lifecycle.GetDurationBetween("query_start", "gather_finish")),
+		RowCount:   len(r.Rows),
+	}
+}
+
+// buildProfile builds the execution profile containing the 'limit' slowest controls and queries
+func (e *ExecutionTree) buildProfile(limit int) *ExecutionProfile {
+	profile := &ExecutionProfile{}
+	queryMap := make(map[string]*QueryProfileEntry)
+
+	for _, run := range e.ControlRuns {
+		if run.Profile == nil {
+			continue
+		}
+		profile.SlowestControls = append(profile.SlowestControls, &ControlProfileEntry{
+			ControlId:         run.ControlId,
+			CacheHit:          run.CacheHit,
+			ControlRunProfile: run.Profile,
+		})
+
+		// cached results did not execute the query
+		if run.CacheHit {
+			continue
+		}
+		queryName := run.Control.Name()
+		if run.Control.Query != nil {
+			queryName = run.Control.Query.Name()
+		}
+		entry, ok := queryMap[queryName]
+		if !ok {
+			entry = &QueryProfileEntry{Query: queryName}
+			queryMap[queryName] = entry
+			profile.SlowestQueries = append(profile.SlowestQueries, entry)
+		}
+		entry.Executions++
+		entry.TotalQueryMs += run.Profile.QueryMs
+		entry.RowCount += run.Profile.RowCount
+		if run.Profile.QueryMs > entry.MaxQueryMs {
+			entry.MaxQueryMs = run.Profile.QueryMs
+		}
+	}
+
+	sort.SliceStable(profile.SlowestControls, func(i, j int) bool {
+		return profile.SlowestControls[i].DurationMs > profile.SlowestControls[j].DurationMs
+	})
+	sort.SliceStable(profile.SlowestQueries, func(i, j int) bool {
+		return profile.SlowestQueries[i].TotalQueryMs > profile.SlowestQueries[j].TotalQueryMs
+	})
+	if len(profile.SlowestControls) > limit {
+		profile.SlowestControls = profile.SlowestControls[:limit]
+	}
+	if len(profile.SlowestQueries) > limit {
+		profile.SlowestQueries = profile.SlowestQueries[:limit]
+	}
+	return profile
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
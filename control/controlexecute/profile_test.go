@@ -0,0 +1,56 @@
+package controlexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestBuildProfile(t *testing.T) {
+	sharedQuery := &modconfig.Query{FullName: "mod.query.shared"}
+	newRun := func(name string, query *modconfig.Query, durationMs, queryMs float64, rows int, cacheHit bool) *ControlRun {
+		return &ControlRun{
+			Control:   &modconfig.Control{FullName: "mod.control." + name, Query: query},
+			ControlId: "control." + name,
+			CacheHit:  cacheHit,
+			Profile:   &ControlRunProfile{DurationMs: durationMs, QueryMs: queryMs, RowCount: rows},
+		}
+	}
+	tree := &ExecutionTree{
+		ControlRuns: []*ControlRun{
+			newRun("c1", sharedQuery, 100, 90, 10, false),
+			newRun("c2", sharedQuery, 150, 140, 5, false),
+			newRun("c3", nil, 200, 180, 1, false),
+			newRun("c4", nil, 300, 0, 7, true),
+			{Control: &modconfig.Control{FullName: "mod.control.unprofiled"}},
+		},
+	}
+
+	profile := tree.buildProfile(3)
+
+	var controlIds []string
+	for _, c := range profile.SlowestControls {
+		controlIds = append(controlIds, c.ControlId)
+	}
+	expectedControls := []string{"control.c4", "control.c3", "control.c2"}
+	if len(controlIds) != len(expectedControls) {
+		t.Fatalf("expected slowest controls %v, got %v", expectedControls, controlIds)
+	}
+	for i := range expectedControls {
+		if controlIds[i] != expectedControls[i] {
+			t.Fatalf("expected slowest controls %v, got %v", expectedControls, controlIds)
+		}
+	}
+
+	// the cached control did not execute its query, so only 2 queries are profiled
+	if len(profile.SlowestQueries) != 2 {
+		t.Fatalf("expected 2 queries, got %d", len(profile.SlowestQueries))
+	}
+	shared := profile.SlowestQueries[0]
+	if shared.Query != "mod.query.shared" || shared.Executions != 2 || shared.TotalQueryMs != 230 || shared.MaxQueryMs != 140 || shared.RowCount != 15 {
+		t.Errorf("unexpected shared query profile: %+v", shared)
+	}
+	if profile.SlowestQueries[1].Query != "mod.control.c3" {
+		t.Errorf("expected inline query to be profiled by control name, got %s", profile.SlowestQueries[1].Query)
+	}
+}
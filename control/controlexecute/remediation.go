@@ -0,0 +1,116 @@
+package controlexecute
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/version"
+)
+
+// RemediationScript builds a shell script containing the remediation command
+// for every alarmed resource of the controls which were run
+// the script is only ever written - it is never executed by steampipe
+type RemediationScript struct {
+	sections []string
+	// count of the remediation commands added to the script
+	CommandCount int
+}
+
+func NewRemediationScript() *RemediationScript {
+	return &RemediationScript{}
+}
+
+// Add adds remediation commands for all alarmed results in the execution tree
+func (s *RemediationScript) Add(tree *ExecutionTree) {
+	for _, run := range tree.ControlRuns {
+		if section := s.runSection(run); section != "" {
+			s.sections = append(s.sections, section)
+		}
+	}
+}
+
+// Write writes the script to the given path
+func (s *RemediationScript) Write(path string) error {
+	header := fmt.Sprintf(`#!/bin/sh
+# Remediation script generated by Steampipe v%s at %s
+# This script has NOT been run. Review every command before running it.
+`, version.SteampipeVersion.String(), time.Now().Format(time.RFC3339))
+
+	content := strings.Join(append([]string{header}, s.sections...), "\n")
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+func (s *RemediationScript) runSection(run *ControlRun) string {
+	var alarms []*ResultRow
+	for _, row := range run.Rows {
+		if row.Status == constants.ControlAlarm {
+			alarms = append(alarms, row)
+		}
+	}
+	if len(alarms) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# %s: %s\n", run.ControlId, run.Title))
+
+	remediation := run.Control.Remediation
+	if remediation == nil || remediation.Command == nil {
+		b.WriteString("# no remediation command is defined for this control\n")
+		return b.String()
+	}
+
+	commandTemplate, err := template.New(run.ControlId).
+		Option("missingkey=error").
+		Parse(typehelpers.SafeString(remediation.Command))
+	if err != nil {
+		b.WriteString(fmt.Sprintf("# invalid remediation command: %s\n", commentString(err.Error())))
+		return b.String()
+	}
+
+	for _, row := range alarms {
+		b.WriteString(fmt.Sprintf("\n# %s: %s\n", commentString(row.Resource), commentString(row.Reason)))
+		var command bytes.Buffer
+		if err := commandTemplate.Execute(&command, remediationTemplateData(row)); err != nil {
+			b.WriteString(fmt.Sprintf("# failed to render remediation command: %s\n", commentString(err.Error())))
+			continue
+		}
+		b.WriteString(strings.TrimSpace(command.String()))
+		b.WriteString("\n")
+		s.CommandCount++
+	}
+	return b.String()
+}
+
+// remediationTemplateData returns the values available to a remediation command template:
+// the resource, reason and status of the result, the control id and all dimension columns
+// the result values come from the resources being checked, so cannot be trusted - every value is shell-quoted,
+// so it can never be interpreted by the shell as anything other than a single literal argument
+func remediationTemplateData(row *ResultRow) map[string]string {
+	data := map[string]string{
+		"resource":   shellQuote(row.Resource),
+		"reason":     shellQuote(row.Reason),
+		"status":     shellQuote(row.Status),
+		"control_id": shellQuote(row.Run.ControlId),
+	}
+	for _, dim := range row.Dimensions {
+		data[dim.Key] = shellQuote(dim.Value)
+	}
+	return data
+}
+
+// shellQuote quotes a value so it is passed to a shell command as a single literal argument
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// commentString ensures a value does not break out of a single line shell comment
+func commentString(value string) string {
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,63 @@
+package controlexecute
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestRemediationScript(t *testing.T) {
+	command := "aws s3api put-bucket-versioning --bucket {{ .resource }} --region {{ .region }}"
+	withCommand := &ControlRun{
+		ControlId: "control.c1",
+		Title:     "Versioning enabled",
+		Control:   &modconfig.Control{Remediation: &modconfig.ControlRemediation{Command: &command}},
+		Summary:   &controlstatus.StatusSummary{},
+	}
+	withCommand.Rows = []*ResultRow{
+		{Status: "alarm", Resource: "bucket'1", Reason: "disabled", Dimensions: []Dimension{{"region", "us-east-1; rm -rf /"}}, Run: withCommand},
+		{Status: "ok", Resource: "bucket2", Reason: "enabled", Dimensions: []Dimension{{"region", "us-east-1"}}, Run: withCommand},
+		{Status: "alarm", Resource: "bucket3", Reason: "disabled", Run: withCommand},
+	}
+	withoutCommand := &ControlRun{
+		ControlId: "control.c2",
+		Title:     "Encryption enabled",
+		Control:   &modconfig.Control{},
+		Summary:   &controlstatus.StatusSummary{},
+	}
+	withoutCommand.Rows = []*ResultRow{{Status: "alarm", Resource: "bucket4", Run: withoutCommand}}
+
+	script := NewRemediationScript()
+	script.Add(&ExecutionTree{ControlRuns: []*ControlRun{withCommand, withoutCommand}})
+
+	path := filepath.Join(t.TempDir(), "fix.sh")
+	if err := script.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+
+	if script.CommandCount != 1 {
+		t.Errorf("expected 1 remediation command, got %d", script.CommandCount)
+	}
+	expected := []string{
+		`aws s3api put-bucket-versioning --bucket 'bucket'\''1' --region 'us-east-1; rm -rf /'`,
+		"# failed to render remediation command",
+		"# no remediation command is defined for this control",
+	}
+	for _, e := range expected {
+		if !strings.Contains(content, e) {
+			t.Errorf("expected script to contain %q, got:\n%s", e, content)
+		}
+	}
+	if strings.Contains(content, "bucket2") {
+		t.Errorf("expected script not to contain passing resource 'bucket2', got:\n%s", content)
+	}
+}
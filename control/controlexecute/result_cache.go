@@ -0,0 +1,148 @@
+package controlexecute
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe/db/db_common"
+)
+
+// ResultCache persists the results of control runs across check invocations, so a control
+// whose query, search path and connection config are unchanged is not re-executed within the cache TTL
+type ResultCache struct {
+	dir string
+	ttl time.Duration
+}
+
+type resultCacheEntry struct {
+	CachedAt time.Time `json:"cached_at"`
+	*CheckpointEntry
+}
+
+func NewResultCache(dir string, ttl time.Duration) *ResultCache {
+	return &ResultCache{
+		dir: dir,
+		ttl: ttl,
+	}
+}
+
+// Get returns the cached result for the given key, if one exists and has not expired
+func (c *ResultCache) Get(key string) (*CheckpointEntry, bool) {
+	path := c.entryPath(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry resultCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.CheckpointEntry == nil {
+		log.Printf("[WARN] ignoring invalid result cache entry '%s'", path)
+		return nil, false
+	}
+	if time.Since(entry.CachedAt) > c.ttl {
+		// remove the expired entry
+		os.Remove(path)
+		return nil, false
+	}
+	return entry.CheckpointEntry, true
+}
+
+// Add caches the results of a successful control run under the given key
+func (c *ResultCache) Add(key string, run *ControlRun) error {
+	entry := resultCacheEntry{
+		CachedAt: time.Now(),
+		CheckpointEntry: &CheckpointEntry{
+			Summary:       run.Summary,
+			Rows:          run.Rows,
+			DimensionKeys: run.DimensionKeys,
+			Duration:      run.Duration,
+		},
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file then rename, so a concurrent check run never reads a truncated entry
+	path := c.entryPath(key)
+	tmpPath := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Prune removes all expired entries from the cache directory, along with any invalid entries
+func (c *ResultCache) Prune() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		log.Printf("[WARN] failed to read result cache directory '%s': %s", c.dir, err.Error())
+		return
+	}
+	for _, dirEntry := range entries {
+		if dirEntry.IsDir() || filepath.Ext(dirEntry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(c.dir, dirEntry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var entry resultCacheEntry
+		if err := json.Unmarshal(data, &entry); err == nil && entry.CheckpointEntry != nil && time.Since(entry.CachedAt) <= c.ttl {
+			continue
+		}
+		log.Printf("[TRACE] removing expired result cache entry '%s'", path)
+		os.Remove(path)
+	}
+}
+
+func (c *ResultCache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// ResultCacheKey builds a cache key from the resolved control SQL (which includes the args), the search path
+// and a hash of the connection config
+func ResultCacheKey(executeSQL string, searchPath []string, connectionMap map[string]string) string {
+	h := sha256.New()
+	h.Write([]byte(executeSQL))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(searchPath, ",")))
+
+	names := make([]string, 0, len(connectionMap))
+	for name := range connectionMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h.Write([]byte{0})
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(connectionMap[name]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// connectionConfigMap returns a map of connection name to a string representation of its config,
+// used to invalidate cached results when a connection changes
+func connectionConfigMap(client db_common.Client) map[string]string {
+	res := make(map[string]string)
+	connectionMap := client.ConnectionMap()
+	if connectionMap == nil {
+		return res
+	}
+	for name, data := range *connectionMap {
+		config := ""
+		if data.Connection != nil {
+			config = data.Connection.Config
+		}
+		res[name] = fmt.Sprintf("%s|%s|%s|%s", data.Plugin, data.SchemaHash, data.ModTime.String(), config)
+	}
+	return res
+}
@@ -0,0 +1,104 @@
+package controlexecute
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestResultCache(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewResultCache(dir, time.Minute)
+
+	key := ResultCacheKey("select 1", []string{"public", "aws"}, map[string]string{"aws": "config"})
+	if _, ok := cache.Get(key); ok {
+		t.Fatal("expected empty cache")
+	}
+
+	run := &ControlRun{
+		Control: &modconfig.Control{FullName: "mod.control.c1"},
+		Summary: &controlstatus.StatusSummary{Alarm: 1},
+		Rows:    []*ResultRow{{Status: "alarm", Resource: "r1"}},
+	}
+	if err := cache.Add(key, run); err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := cache.Get(key)
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if *entry.Summary != *run.Summary || len(entry.Rows) != 1 || entry.Rows[0].Resource != "r1" {
+		t.Errorf("cached results were not restored correctly: %v", entry)
+	}
+
+	// an expired entry is not returned
+	if _, ok := NewResultCache(dir, 0).Get(key); ok {
+		t.Error("expected expired entry not to be returned")
+	}
+}
+
+func TestResultCacheKey(t *testing.T) {
+	connections := map[string]string{"aws": "config", "gcp": "config"}
+	key := ResultCacheKey("select 1", []string{"public"}, connections)
+
+	if other := ResultCacheKey("select 1", []string{"public"}, map[string]string{"gcp": "config", "aws": "config"}); other != key {
+		t.Error("expected key to be independent of connection map ordering")
+	}
+	changes := map[string]string{
+		"sql":         ResultCacheKey("select 2", []string{"public"}, connections),
+		"search path": ResultCacheKey("select 1", []string{"aws", "public"}, connections),
+		"connection":  ResultCacheKey("select 1", []string{"public"}, map[string]string{"aws": "changed", "gcp": "config"}),
+	}
+	for name, other := range changes {
+		if other == key {
+			t.Errorf("expected key to change when the %s changes", name)
+		}
+	}
+}
+
+func TestRunHashQuery(t *testing.T) {
+	sql := "select true"
+	reason := "not applicable"
+	base := runHashQuery("select 1", &modconfig.Control{FullName: "m1.control.c1"})
+
+	changes := map[string]*modconfig.Control{
+		"precondition":        {FullName: "m1.control.c1", Precondition: &modconfig.ControlPrecondition{SQL: &sql}},
+		"precondition reason": {FullName: "m1.control.c1", Precondition: &modconfig.ControlPrecondition{SQL: &sql, Reason: &reason}},
+		"depends on":          {FullName: "m1.control.c1", DependsOn: []*modconfig.Control{{FullName: "m1.control.c2"}}},
+	}
+	seen := map[string]string{base: "none"}
+	for name, control := range changes {
+		query := runHashQuery("select 1", control)
+		if other, ok := seen[query]; ok {
+			t.Errorf("Test: '%s'' FAILED : expected the hashed query to differ from '%s'", name, other)
+		}
+		seen[query] = name
+	}
+}
+
+func TestResultCachePrune(t *testing.T) {
+	dir := t.TempDir()
+	run := &ControlRun{
+		Control: &modconfig.Control{FullName: "mod.control.c1"},
+		Summary: &controlstatus.StatusSummary{Ok: 1},
+	}
+	key := ResultCacheKey("select 1", []string{"public"}, nil)
+	if err := NewResultCache(dir, time.Minute).Add(key, run); err != nil {
+		t.Fatal(err)
+	}
+
+	// an unexpired entry is kept
+	NewResultCache(dir, time.Minute).Prune()
+	if _, ok := NewResultCache(dir, time.Minute).Get(key); !ok {
+		t.Fatal("expected unexpired entry to be kept")
+	}
+
+	// an expired entry is removed
+	NewResultCache(dir, 0).Prune()
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected expired entry to be removed, got %d entries", len(entries))
+	}
+}
@@ -52,6 +52,8 @@ type ResultGroup struct {
 type GroupSummary struct {
 	Status   controlstatus.StatusSummary            `json:"status"`
 	Severity map[string]controlstatus.StatusSummary `json:"-"`
+	// the weighted compliance score of the group
+	Score *GroupScore `json:"score,omitempty"`
 }
 
 func NewGroupSummary() *GroupSummary {
@@ -248,35 +250,47 @@ func (r *ResultGroup) execute(ctx context.Context, client db_common.Client, para
 	defer log.Printf("[TRACE] end ResultGroup.Execute: %s\n", r.GroupId)
 
 	for _, controlRun := range r.ControlRuns {
-		if utils.IsContextCancelled(ctx) {
-			controlRun.setError(ctx, ctx.Err())
-			continue
-		}
+		executeControlRun(ctx, controlRun, client, parallelismLock)
+	}
+	for _, child := range r.Groups {
+		child.execute(ctx, client, parallelismLock)
+	}
+}
 
-		if viper.GetBool(constants.ArgDryRun) {
-			controlRun.skip(ctx)
-			continue
-		}
+// executeControlRun starts the execution of a control run in a goroutine
+// if the control depends on controls in the execution tree which have not yet finished, the run is deferred
+// and is executed by the execution tree once its dependencies are complete
+func executeControlRun(ctx context.Context, controlRun *ControlRun, client db_common.Client, parallelismLock *semaphore.Weighted) {
+	if utils.IsContextCancelled(ctx) {
+		controlRun.setError(ctx, ctx.Err())
+		return
+	}
 
-		err := parallelismLock.Acquire(ctx, 1)
-		if err != nil {
-			controlRun.setError(ctx, err)
-			continue
-		}
+	if viper.GetBool(constants.ArgDryRun) {
+		controlRun.skip(ctx)
+		return
+	}
 
-		go func(c context.Context, run *ControlRun) {
-			defer func() {
-				if r := recover(); r != nil {
-					// if the Execute panic'ed, set it as an error
-					run.setError(ctx, helpers.ToError(r))
-				}
-				// Release in defer, so that we don't retain the lock even if there's a panic inside
-				parallelismLock.Release(1)
-			}()
-			run.execute(c, client)
-		}(ctx, controlRun)
+	if controlRun.hasPendingDependencies() {
+		controlRun.Tree.deferRun(controlRun)
+		return
 	}
-	for _, child := range r.Groups {
-		child.execute(ctx, client, parallelismLock)
+
+	err := parallelismLock.Acquire(ctx, 1)
+	if err != nil {
+		controlRun.setError(ctx, err)
+		return
 	}
+
+	go func(c context.Context, run *ControlRun) {
+		defer func() {
+			if r := recover(); r != nil {
+				// if the Execute panic'ed, set it as an error
+				run.setError(ctx, helpers.ToError(r))
+			}
+			// Release in defer, so that we don't retain the lock even if there's a panic inside
+			parallelismLock.Release(1)
+		}()
+		run.execute(c, client)
+	}(ctx, controlRun)
 }
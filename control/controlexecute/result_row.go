@@ -23,6 +23,8 @@ type ResultRow struct {
 	Status string `json:"status" csv:"status"`
 	// dimensions for this row
 	Dimensions []Dimension `json:"dimensions"`
+	// additional data captured for this row, as specified by the control evidence block
+	Evidence map[string]interface{} `json:"evidence,omitempty"`
 	// parent control run
 	Run *ControlRun `json:"-"`
 	// source control
@@ -49,6 +51,14 @@ func (r *ResultRow) AddDimension(c *sql.ColumnType, val interface{}) {
 	}
 }
 
+// AddEvidence adds a value to the evidence map
+func (r *ResultRow) AddEvidence(key string, val interface{}) {
+	if r.Evidence == nil {
+		r.Evidence = make(map[string]interface{})
+	}
+	r.Evidence[key] = val
+}
+
 func NewResultRow(run *ControlRun, row *queryresult.RowResult, colTypes []*sql.ColumnType) (*ResultRow, error) {
 	// validate the required columns exist in the result
 	if err := validateColumns(colTypes); err != nil {
@@ -78,6 +88,11 @@ func NewResultRow(run *ControlRun, row *queryresult.RowResult, colTypes []*sql.C
 			}
 			res.Status = status
 		default:
+			// if this column is captured as evidence, store the raw value
+			if run.isEvidenceColumn(c.Name()) {
+				res.AddEvidence(c.Name(), row.Data[i])
+				continue
+			}
 			// if this is a scalar type, add to dimensions
 			res.AddDimension(c, row.Data[i])
 		}
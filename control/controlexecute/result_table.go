@@ -0,0 +1,88 @@
+package controlexecute
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/db/db_common"
+)
+
+// CheckResultTableRow is a single control result, as loaded into the check result table
+type CheckResultTableRow struct {
+	Target             string                 `column:"target,text"`
+	RunTime            string                 `column:"run_time,timestamptz"`
+	GroupId            string                 `column:"group_id,text"`
+	GroupTitle         string                 `column:"group_title,text"`
+	ControlId          string                 `column:"control_id,text"`
+	ControlTitle       string                 `column:"control_title,text"`
+	ControlDescription string                 `column:"control_description,text"`
+	Severity           string                 `column:"severity,text"`
+	Tags               map[string]string      `column:"tags,jsonb"`
+	Resource           string                 `column:"resource,text"`
+	Status             string                 `column:"status,text"`
+	Reason             string                 `column:"reason,text"`
+	Dimensions         map[string]string      `column:"dimensions,jsonb"`
+	Evidence           map[string]interface{} `column:"evidence,jsonb"`
+}
+
+// ResultTableRows returns a CheckResultTableRow for every control result in the tree
+// a control run which failed without returning any rows is represented by a single error row
+func (e *ExecutionTree) ResultTableRows(target string) []interface{} {
+	var res []interface{}
+	runTime := e.StartTime.Format(time.RFC3339)
+	for _, run := range e.ControlRuns {
+		newRow := func(resource, status, reason string) *CheckResultTableRow {
+			row := &CheckResultTableRow{
+				Target:             target,
+				RunTime:            runTime,
+				ControlId:          run.Control.Name(),
+				ControlTitle:       run.Title,
+				ControlDescription: run.Description,
+				Severity:           run.Severity,
+				Tags:               run.Tags,
+				Resource:           resource,
+				Status:             status,
+				Reason:             reason,
+			}
+			if run.Group != nil {
+				row.GroupId = run.Group.GroupId
+				row.GroupTitle = run.Group.Title
+			}
+			return row
+		}
+
+		if len(run.Rows) == 0 && run.GetError() != nil {
+			res = append(res, newRow("", constants.ControlError, run.GetError().Error()))
+			continue
+		}
+		for _, resultRow := range run.Rows {
+			row := newRow(resultRow.Resource, resultRow.Status, resultRow.Reason)
+			row.Dimensions = make(map[string]string, len(resultRow.Dimensions))
+			for _, d := range resultRow.Dimensions {
+				row.Dimensions[d.Key] = d.Value
+			}
+			row.Evidence = resultRow.Evidence
+			res = append(res, row)
+		}
+	}
+	return res
+}
+
+// LoadResultTable loads the results of the tree into the check result table of the service database,
+// so they can be queried alongside live data
+// if replace is set, any existing table is replaced, otherwise the results are appended
+func (e *ExecutionTree) LoadResultTable(ctx context.Context, client db_common.Client, target string, replace bool) error {
+	sessionResult := client.AcquireSession(ctx)
+	if sessionResult.Error != nil {
+		return sessionResult.Error
+	}
+	defer sessionResult.Session.Close(false)
+
+	if replace {
+		if err := db_common.CreateResultTable(ctx, sessionResult.Session, constants.CheckResultTable, CheckResultTableRow{}); err != nil {
+			return err
+		}
+	}
+	return db_common.InsertResultTableRows(ctx, sessionResult.Session, constants.CheckResultTable, e.ResultTableRows(target))
+}
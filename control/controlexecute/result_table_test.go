@@ -0,0 +1,50 @@
+package controlexecute
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestResultTableRows(t *testing.T) {
+	group := &ResultGroup{GroupId: "mod.benchmark.b1", Title: "Benchmark 1"}
+	tree := &ExecutionTree{
+		ControlRuns: []*ControlRun{
+			{
+				Control: &modconfig.Control{FullName: "mod.control.c1"},
+				Group:   group,
+				Summary: &controlstatus.StatusSummary{Ok: 1, Alarm: 1},
+				Rows: []*ResultRow{
+					{Status: "ok", Resource: "r1", Reason: "r1 ok"},
+					{Status: "alarm", Resource: "r2", Reason: "r2 alarm", Dimensions: []Dimension{{"region", "us-east-1"}}},
+				},
+			},
+			{
+				Control:  &modconfig.Control{FullName: "mod.control.c2"},
+				Group:    group,
+				Summary:  &controlstatus.StatusSummary{Error: 1},
+				runError: fmt.Errorf("query failed"),
+			},
+		},
+	}
+
+	rows := tree.ResultTableRows("benchmark.b1")
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	alarm := rows[1].(*CheckResultTableRow)
+	if alarm.ControlId != "mod.control.c1" || alarm.GroupId != "mod.benchmark.b1" || alarm.Target != "benchmark.b1" {
+		t.Errorf("unexpected row identity: %+v", alarm)
+	}
+	if alarm.Dimensions["region"] != "us-east-1" {
+		t.Errorf("expected dimension region=us-east-1, got %v", alarm.Dimensions)
+	}
+
+	errorRow := rows[2].(*CheckResultTableRow)
+	if errorRow.Status != "error" || errorRow.Reason != "query failed" {
+		t.Errorf("expected error row for failed control, got %+v", errorRow)
+	}
+}
@@ -0,0 +1,90 @@
+package controlexecute
+
+import (
+	"strings"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// the weight used for controls which do not specify a weight, keyed by severity
+var defaultSeverityWeights = map[string]int{
+	"critical": 10,
+	"high":     5,
+	"medium":   3,
+	"low":      1,
+}
+
+// the weight used for controls which specify neither a weight nor a recognised severity
+const defaultControlWeight = 1
+
+// GroupScore is the weighted compliance score of a result group
+// Each control contributes its weight multiplied by the proportion of its ok, alarm and error results which are ok.
+// Controls with only info or skip results do not contribute to the score.
+type GroupScore struct {
+	// the sum of the weights of the scored controls
+	TotalWeight float64 `json:"total_weight"`
+	// the sum of the weighted proportions of passing results
+	PassedWeight float64 `json:"passed_weight"`
+	// the score as a percentage
+	Percent float64 `json:"percent"`
+}
+
+// controlWeight returns the weight of the control, falling back to the default weight for its severity
+func controlWeight(control *modconfig.Control) int {
+	if control.Weight != nil {
+		return *control.Weight
+	}
+	if weight, ok := defaultSeverityWeights[strings.ToLower(typehelpers.SafeString(control.Severity))]; ok {
+		return weight
+	}
+	return defaultControlWeight
+}
+
+func (s *GroupScore) addControlRun(run *ControlRun) {
+	if run.Summary == nil || run.Weight == 0 {
+		return
+	}
+	scoredCount := run.Summary.Ok + run.Summary.Alarm + run.Summary.Error
+	if scoredCount == 0 {
+		return
+	}
+	weight := float64(run.Weight)
+	s.TotalWeight += weight
+	s.PassedWeight += weight * float64(run.Summary.Ok) / float64(scoredCount)
+}
+
+func (s *GroupScore) addGroupScore(other *GroupScore) {
+	if other == nil {
+		return
+	}
+	s.TotalWeight += other.TotalWeight
+	s.PassedWeight += other.PassedWeight
+}
+
+// calculateScores calculates the score of the group and all descendant groups
+func (r *ResultGroup) calculateScores() {
+	for _, child := range r.Groups {
+		child.calculateScores()
+	}
+	r.updateScore()
+}
+
+// updateScore sets the score of the group from its control runs and the scores of its child groups
+// (which must already have been calculated)
+// if the group contains no scored controls, the score is nil
+func (r *ResultGroup) updateScore() {
+	score := &GroupScore{}
+	for _, run := range r.ControlRuns {
+		score.addControlRun(run)
+	}
+	for _, child := range r.Groups {
+		score.addGroupScore(child.Summary.Score)
+	}
+	if score.TotalWeight == 0 {
+		r.Summary.Score = nil
+		return
+	}
+	score.Percent = score.PassedWeight * 100 / score.TotalWeight
+	r.Summary.Score = score
+}
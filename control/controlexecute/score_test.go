@@ -0,0 +1,68 @@
+package controlexecute
+
+import (
+	"math"
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/utils"
+)
+
+func TestControlWeight(t *testing.T) {
+	testCases := map[string]struct {
+		control  *modconfig.Control
+		expected int
+	}{
+		"explicit weight": {&modconfig.Control{Weight: utils.ToIntegerPointer(7), Severity: utils.ToStringPointer("critical")}, 7},
+		"critical":        {&modconfig.Control{Severity: utils.ToStringPointer("critical")}, 10},
+		"upper case":      {&modconfig.Control{Severity: utils.ToStringPointer("HIGH")}, 5},
+		"no severity":     {&modconfig.Control{}, defaultControlWeight},
+		"unknown":         {&modconfig.Control{Severity: utils.ToStringPointer("none")}, defaultControlWeight},
+	}
+	for name, test := range testCases {
+		if weight := controlWeight(test.control); weight != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %d, got %d", name, test.expected, weight)
+		}
+	}
+}
+
+func TestCalculateScores(t *testing.T) {
+	newRun := func(weight int, summary controlstatus.StatusSummary) *ControlRun {
+		return &ControlRun{Weight: weight, Summary: &summary}
+	}
+	child := &ResultGroup{
+		Summary: NewGroupSummary(),
+		ControlRuns: []*ControlRun{
+			// half passing, weight 10 - contributes 5 of 10
+			newRun(10, controlstatus.StatusSummary{Ok: 1, Alarm: 1}),
+			// only info and skip results - not scored
+			newRun(5, controlstatus.StatusSummary{Info: 1, Skip: 2}),
+		},
+	}
+	root := &ResultGroup{
+		Summary: NewGroupSummary(),
+		Groups:  []*ResultGroup{child},
+		ControlRuns: []*ControlRun{
+			// all passing, weight 2 - contributes 2 of 2
+			newRun(2, controlstatus.StatusSummary{Ok: 3}),
+			// zero weight - not scored
+			newRun(0, controlstatus.StatusSummary{Alarm: 3}),
+		},
+	}
+	empty := &ResultGroup{Summary: NewGroupSummary()}
+	root.Groups = append(root.Groups, empty)
+
+	root.calculateScores()
+
+	if child.Summary.Score == nil || child.Summary.Score.Percent != 50 {
+		t.Errorf("expected child score of 50%%, got %v", child.Summary.Score)
+	}
+	if empty.Summary.Score != nil {
+		t.Errorf("expected group with no scored controls to have no score, got %v", empty.Summary.Score)
+	}
+	// (5 + 2) / (10 + 2)
+	if root.Summary.Score == nil || math.Abs(root.Summary.Score.Percent-700.0/12) > 0.0001 {
+		t.Errorf("expected root score of %.2f%%, got %v", 700.0/12, root.Summary.Score)
+	}
+}
@@ -0,0 +1,49 @@
+package controlexecute
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// Shard identifies a deterministic subset of the controls in a check run,
+// allowing a large benchmark to be split across multiple processes
+type Shard struct {
+	// 1-based index of this shard
+	Index int
+	// total number of shards
+	Count int
+}
+
+// ParseShard parses a shard argument of the form 'N/M', where 1 <= N <= M
+func ParseShard(arg string) (*Shard, error) {
+	parts := strings.Split(arg, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid shard '%s' - shard must be of the form 'N/M', e.g. '1/4'", arg)
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid shard '%s' - shard index must be an integer", arg)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid shard '%s' - shard count must be an integer", arg)
+	}
+	if count < 1 || index < 1 || index > count {
+		return nil, fmt.Errorf("invalid shard '%s' - shard index must be between 1 and the shard count", arg)
+	}
+	return &Shard{Index: index, Count: count}, nil
+}
+
+// Includes returns whether the given control is allocated to this shard
+// controls are allocated by hashing the control name, so the allocation is stable across invocations
+func (s *Shard) Includes(controlName string) bool {
+	h := fnv.New32a()
+	h.Write([]byte(controlName))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index-1
+}
+
+func (s *Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
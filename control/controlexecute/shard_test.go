@@ -0,0 +1,56 @@
+package controlexecute
+
+import (
+	"fmt"
+	"testing"
+)
+
+type parseShardTest struct {
+	input    string
+	expected *Shard
+}
+
+var parseShardTestCases = map[string]parseShardTest{
+	"first":         {input: "1/4", expected: &Shard{Index: 1, Count: 4}},
+	"last":          {input: "4/4", expected: &Shard{Index: 4, Count: 4}},
+	"single":        {input: "1/1", expected: &Shard{Index: 1, Count: 1}},
+	"zero index":    {input: "0/4", expected: nil},
+	"index too big": {input: "5/4", expected: nil},
+	"no count":      {input: "1", expected: nil},
+	"not a number":  {input: "a/b", expected: nil},
+}
+
+func TestParseShard(t *testing.T) {
+	for name, test := range parseShardTestCases {
+		shard, err := ParseShard(test.input)
+		if test.expected == nil {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected error, got %v", name, shard)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			continue
+		}
+		if *shard != *test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, shard)
+		}
+	}
+}
+
+func TestShardIncludesEachControlOnce(t *testing.T) {
+	const count = 5
+	for i := 0; i < 200; i++ {
+		controlName := fmt.Sprintf("control_%d", i)
+		matches := 0
+		for index := 1; index <= count; index++ {
+			if (&Shard{Index: index, Count: count}).Includes(controlName) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			t.Errorf("control '%s' was included in %d shards, expected 1", controlName, matches)
+		}
+	}
+}
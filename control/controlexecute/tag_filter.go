@@ -0,0 +1,360 @@
+package controlexecute
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// TagFilter is a boolean expression evaluated against the tags of a control
+type TagFilter interface {
+	Matches(tags map[string]string) bool
+	String() string
+}
+
+// ParseTagFilter parses a tag filter expression, for example:
+//
+//	cis_level=1 && !(service in [iam, s3])
+//
+// the supported syntax is:
+// - 'key=value' and 'key!=value' compare a tag value
+// - 'key in [v1, v2]' matches any of a set of values
+// - 'key' on its own matches controls which have the tag
+// - values may contain the wildcards '*' and '?', and may be quoted with ' or "
+// - expressions may be combined with '&&', '||', '!' and parentheses
+func ParseTagFilter(expr string) (TagFilter, error) {
+	tokens, err := tokenizeTagFilter(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag filter '%s': %s", expr, err.Error())
+	}
+	p := &tagFilterParser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err == nil && !p.done() {
+		err = fmt.Errorf("unexpected '%s'", p.peek().value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag filter '%s': %s", expr, err.Error())
+	}
+	return filter, nil
+}
+
+type tagFilterAnd struct{ left, right TagFilter }
+
+func (f *tagFilterAnd) Matches(tags map[string]string) bool {
+	return f.left.Matches(tags) && f.right.Matches(tags)
+}
+
+func (f *tagFilterAnd) String() string {
+	return fmt.Sprintf("(%s && %s)", f.left, f.right)
+}
+
+type tagFilterOr struct{ left, right TagFilter }
+
+func (f *tagFilterOr) Matches(tags map[string]string) bool {
+	return f.left.Matches(tags) || f.right.Matches(tags)
+}
+
+func (f *tagFilterOr) String() string {
+	return fmt.Sprintf("(%s || %s)", f.left, f.right)
+}
+
+type tagFilterNot struct{ filter TagFilter }
+
+func (f *tagFilterNot) Matches(tags map[string]string) bool {
+	return !f.filter.Matches(tags)
+}
+
+func (f *tagFilterNot) String() string {
+	return fmt.Sprintf("!%s", f.filter)
+}
+
+// tagFilterExists matches controls which have the tag, with any value
+type tagFilterExists struct{ key string }
+
+func (f *tagFilterExists) Matches(tags map[string]string) bool {
+	_, ok := tags[f.key]
+	return ok
+}
+
+func (f *tagFilterExists) String() string {
+	return f.key
+}
+
+// tagFilterValues matches controls whose tag value matches any of the value patterns
+// 'key=value' and 'key!=value' are represented as a single value set
+type tagFilterValues struct {
+	key      string
+	values   []string
+	patterns []*regexp.Regexp
+	negate   bool
+}
+
+func newTagFilterValues(key string, values []string, negate bool) *tagFilterValues {
+	f := &tagFilterValues{key: key, values: values, negate: negate}
+	for _, v := range values {
+		f.patterns = append(f.patterns, wildcardPattern(v))
+	}
+	return f
+}
+
+func (f *tagFilterValues) Matches(tags map[string]string) bool {
+	value, ok := tags[f.key]
+	if !ok {
+		// a missing tag never equals a value, so it only matches a negated comparison
+		return f.negate
+	}
+	for _, p := range f.patterns {
+		if p.MatchString(value) {
+			return !f.negate
+		}
+	}
+	return f.negate
+}
+
+func (f *tagFilterValues) String() string {
+	if len(f.values) == 1 {
+		op := "="
+		if f.negate {
+			op = "!="
+		}
+		return fmt.Sprintf("%s%s%s", f.key, op, f.values[0])
+	}
+	res := fmt.Sprintf("%s in [%s]", f.key, strings.Join(f.values, ", "))
+	if f.negate {
+		res = fmt.Sprintf("!(%s)", res)
+	}
+	return res
+}
+
+// wildcardPattern converts a value containing '*' and '?' wildcards into an anchored regular expression
+func wildcardPattern(value string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range value {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+type tagFilterTokenType int
+
+const (
+	tagFilterTokenWord tagFilterTokenType = iota
+	tagFilterTokenString
+	tagFilterTokenSymbol
+)
+
+type tagFilterToken struct {
+	tokenType tagFilterTokenType
+	value     string
+}
+
+// the symbols of the tag filter language - 2 character symbols must precede their 1 character prefixes
+var tagFilterSymbols = []string{"&&", "||", "!=", "!", "=", "(", ")", "[", "]", ","}
+
+func tokenizeTagFilter(expr string) ([]tagFilterToken, error) {
+	var tokens []tagFilterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", i+1)
+			}
+			tokens = append(tokens, tagFilterToken{tagFilterTokenString, string(runes[i+1 : end])})
+			i = end + 1
+		case isTagFilterWordRune(r):
+			end := i
+			for end < len(runes) && isTagFilterWordRune(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, tagFilterToken{tagFilterTokenWord, string(runes[i:end])})
+			i = end
+		default:
+			symbol := ""
+			for _, s := range tagFilterSymbols {
+				if strings.HasPrefix(string(runes[i:]), s) {
+					symbol = s
+					break
+				}
+			}
+			if symbol == "" {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", r, i+1)
+			}
+			tokens = append(tokens, tagFilterToken{tagFilterTokenSymbol, symbol})
+			i += len([]rune(symbol))
+		}
+	}
+	return tokens, nil
+}
+
+func isTagFilterWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.:/*?@", r)
+}
+
+type tagFilterParser struct {
+	tokens []tagFilterToken
+	pos    int
+}
+
+func (p *tagFilterParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *tagFilterParser) peek() tagFilterToken {
+	if p.done() {
+		return tagFilterToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *tagFilterParser) isSymbol(symbol string) bool {
+	token := p.peek()
+	return !p.done() && token.tokenType == tagFilterTokenSymbol && token.value == symbol
+}
+
+func (p *tagFilterParser) expectSymbol(symbol string) error {
+	if !p.isSymbol(symbol) {
+		return p.unexpected(fmt.Sprintf("'%s'", symbol))
+	}
+	p.pos++
+	return nil
+}
+
+func (p *tagFilterParser) unexpected(expected string) error {
+	if p.done() {
+		return fmt.Errorf("expected %s but reached end of expression", expected)
+	}
+	return fmt.Errorf("expected %s but got '%s'", expected, p.peek().value)
+}
+
+// or := and ('||' and)*
+func (p *tagFilterParser) parseOr() (TagFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isSymbol("||") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &tagFilterOr{left, right}
+	}
+	return left, nil
+}
+
+// and := unary ('&&' unary)*
+func (p *tagFilterParser) parseAnd() (TagFilter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isSymbol("&&") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &tagFilterAnd{left, right}
+	}
+	return left, nil
+}
+
+// unary := '!' unary | '(' or ')' | comparison
+func (p *tagFilterParser) parseUnary() (TagFilter, error) {
+	if p.isSymbol("!") {
+		p.pos++
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &tagFilterNot{filter}, nil
+	}
+	if p.isSymbol("(") {
+		p.pos++
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return filter, nil
+	}
+	return p.parseComparison()
+}
+
+// comparison := key | key '=' value | key '!=' value | key 'in' '[' value (',' value)* ']'
+func (p *tagFilterParser) parseComparison() (TagFilter, error) {
+	key, err := p.parseValue("tag key")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.isSymbol("="), p.isSymbol("!="):
+		negate := p.peek().value == "!="
+		p.pos++
+		value, err := p.parseValue("tag value")
+		if err != nil {
+			return nil, err
+		}
+		return newTagFilterValues(key, []string{value}, negate), nil
+	case !p.done() && p.peek().tokenType == tagFilterTokenWord && p.peek().value == "in":
+		p.pos++
+		values, err := p.parseValueSet()
+		if err != nil {
+			return nil, err
+		}
+		return newTagFilterValues(key, values, false), nil
+	}
+	return &tagFilterExists{key}, nil
+}
+
+func (p *tagFilterParser) parseValueSet() ([]string, error) {
+	if err := p.expectSymbol("["); err != nil {
+		return nil, err
+	}
+	var values []string
+	for {
+		value, err := p.parseValue("tag value")
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if p.isSymbol("]") {
+			p.pos++
+			return values, nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *tagFilterParser) parseValue(expected string) (string, error) {
+	token := p.peek()
+	if p.done() || token.tokenType == tagFilterTokenSymbol {
+		return "", p.unexpected(expected)
+	}
+	p.pos++
+	return token.value, nil
+}
@@ -0,0 +1,56 @@
+package controlexecute
+
+import (
+	"testing"
+)
+
+type tagFilterTest struct {
+	expr     string
+	tags     map[string]string
+	expected interface{}
+}
+
+var cisIamTags = map[string]string{"cis_level": "1", "service": "iam", "cis_item_id": "1.4"}
+var cisEc2Tags = map[string]string{"cis_level": "1", "service": "ec2", "cis_item_id": "4.1"}
+
+var tagFilterTestCases = map[string]tagFilterTest{
+	"equals":                 {expr: "cis_level=1", tags: cisIamTags, expected: true},
+	"equals mismatch":        {expr: "cis_level=2", tags: cisIamTags, expected: false},
+	"not equals":             {expr: "service!=iam", tags: cisEc2Tags, expected: true},
+	"not equals missing tag": {expr: "pci!=true", tags: cisEc2Tags, expected: true},
+	"exists":                 {expr: "cis_item_id", tags: cisIamTags, expected: true},
+	"not exists":             {expr: "!pci", tags: cisIamTags, expected: true},
+	"in set":                 {expr: "service in [iam, s3]", tags: cisIamTags, expected: true},
+	"negated set":            {expr: "cis_level=1 && !(service in [iam, s3])", tags: cisIamTags, expected: false},
+	"negated set match":      {expr: "cis_level=1 && !(service in [iam, s3])", tags: cisEc2Tags, expected: true},
+	"wildcard":               {expr: "cis_item_id=4.*", tags: cisEc2Tags, expected: true},
+	"wildcard in set":        {expr: "cis_item_id in ['1.?', '2.*']", tags: cisEc2Tags, expected: false},
+	"or":                     {expr: "service=s3 || service=ec2", tags: cisEc2Tags, expected: true},
+	"and binds tighter":      {expr: "service=s3 || service=ec2 && cis_level=2", tags: cisEc2Tags, expected: false},
+	"quoted value":           {expr: `service="ec2"`, tags: cisEc2Tags, expected: true},
+	"unbalanced parentheses": {expr: "(service=s3", expected: "error"},
+	"missing value":          {expr: "service=", expected: "error"},
+	"unterminated string":    {expr: "service='s3", expected: "error"},
+	"trailing operator":      {expr: "service=s3 &&", expected: "error"},
+	"empty set":              {expr: "service in []", expected: "error"},
+	"invalid character":      {expr: "service=s3 & cis_level=1", expected: "error"},
+}
+
+func TestParseTagFilter(t *testing.T) {
+	for name, test := range tagFilterTestCases {
+		filter, err := ParseTagFilter(test.expr)
+		if test.expected == "error" {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected error, got %s", name, filter)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			continue
+		}
+		if matches := filter.Matches(test.tags); matches != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v (parsed as %s)", name, test.expected, matches, filter)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package controlhistory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/db/db_common"
+)
+
+// Store persists the results of check runs to the check history schema of the service database
+type Store struct {
+	client db_common.Client
+}
+
+func NewStore(client db_common.Client) *Store {
+	return &Store{client: client}
+}
+
+// Add persists the results of the execution tree as a single history run, returning the run id
+// the run and all of its control results are written in a single transaction
+func (s *Store) Add(ctx context.Context, target string, tree *controlexecute.ExecutionTree) (string, error) {
+	runId := uuid.New().String()
+
+	return runId, s.withConnection(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		if err := insertRun(ctx, tx, runId, target, tree); err != nil {
+			tx.Rollback()
+			return err
+		}
+		for _, run := range tree.ControlRuns {
+			if err := insertControlResults(ctx, tx, runId, run); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		log.Printf("[TRACE] persisted check history run %s containing %d controls", runId, len(tree.ControlRuns))
+		return tx.Commit()
+	})
+}
+
+// Trend returns the status counts of the most recent history runs, oldest first
+// if item is set, only the results of the given control or benchmark are counted
+// item may be a fully qualified name (mod.benchmark.name) or an unqualified name (benchmark.name)
+func (s *Store) Trend(ctx context.Context, item string, limit int) ([]*TrendRow, error) {
+	var rows []*TrendRow
+	err := s.withConnection(ctx, func(conn *sql.Conn) error {
+		var res *sql.Rows
+		var err error
+		if item == "" {
+			res, err = conn.QueryContext(ctx, runTrendQuery(), limit)
+		} else {
+			res, err = conn.QueryContext(ctx, itemTrendQuery(), item, "."+item, limit)
+		}
+		if err != nil {
+			return err
+		}
+		defer res.Close()
+
+		for res.Next() {
+			row := &TrendRow{}
+			var score sql.NullFloat64
+			if err := res.Scan(&row.RunId, &row.Target, &row.StartTime, &row.Summary.Ok, &row.Summary.Alarm, &row.Summary.Info, &row.Summary.Skip, &row.Summary.Error, &score); err != nil {
+				return err
+			}
+			if score.Valid {
+				row.Score = &score.Float64
+			}
+			rows = append(rows, row)
+		}
+		return res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the queries return the most recent runs first - reverse to show the oldest first
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+	calculateAlarmChanges(rows)
+	return rows, nil
+}
+
+func (s *Store) withConnection(ctx context.Context, f func(conn *sql.Conn) error) error {
+	sessionResult := s.client.AcquireSession(ctx)
+	if sessionResult.Error != nil {
+		return sessionResult.Error
+	}
+	defer sessionResult.Session.Close(false)
+
+	if err := f(sessionResult.Session.Connection); err != nil {
+		return fmt.Errorf("check history: %s", err.Error())
+	}
+	return nil
+}
+
+func insertRun(ctx context.Context, tx *sql.Tx, runId, target string, tree *controlexecute.ExecutionTree) error {
+	status := tree.Root.Summary.Status
+	var score interface{}
+	if tree.Root.Summary.Score != nil {
+		score = tree.Root.Summary.Score.Percent
+	}
+	_, err := tx.ExecContext(ctx,
+		fmt.Sprintf(`insert into %s (run_id, target, start_time, end_time, ok, alarm, info, skip, error, score)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`, historyTable(constants.CheckHistoryRunTable)),
+		runId, target, tree.StartTime, tree.EndTime, status.Ok, status.Alarm, status.Info, status.Skip, status.Error, score)
+	return err
+}
+
+func insertControlResults(ctx context.Context, tx *sql.Tx, runId string, run *controlexecute.ControlRun) error {
+	query := fmt.Sprintf(`insert into %s (run_id, control_id, control_title, severity, benchmarks, resource, status, reason, dimensions)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9::jsonb)`, historyTable(constants.CheckHistoryResultTable))
+	benchmarks := benchmarkNames(run)
+
+	// a control which failed to run has no rows - record a single error result so the failure shows in the trend
+	if len(run.Rows) == 0 && run.GetError() != nil {
+		_, err := tx.ExecContext(ctx, query, runId, run.Control.Name(), run.Title, run.Severity, benchmarks, nil, constants.ControlError, run.GetError().Error(), nil)
+		return err
+	}
+
+	for _, row := range run.Rows {
+		dimensions, err := dimensionsJson(row.Dimensions)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, runId, run.Control.Name(), run.Title, run.Severity, benchmarks, row.Resource, row.Status, row.Reason, dimensions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// benchmarkNames returns the names of all benchmarks (and mods) containing the control run
+func benchmarkNames(run *controlexecute.ControlRun) []string {
+	var names []string
+	for group := run.Group; group != nil; group = group.Parent {
+		if group.GroupId != controlexecute.RootResultGroupName {
+			names = append(names, group.GroupId)
+		}
+	}
+	return names
+}
+
+func dimensionsJson(dimensions []controlexecute.Dimension) (string, error) {
+	dimensionMap := make(map[string]string, len(dimensions))
+	for _, d := range dimensions {
+		dimensionMap[d.Key] = d.Value
+	}
+	data, err := json.Marshal(dimensionMap)
+	return string(data), err
+}
+
+func historyTable(table string) string {
+	return fmt.Sprintf("%s.%s", constants.CheckHistorySchema, table)
+}
+
+func runTrendQuery() string {
+	return fmt.Sprintf(`select run_id, target, start_time, ok, alarm, info, skip, error, score
+from %s
+order by start_time desc
+limit $1`, historyTable(constants.CheckHistoryRunTable))
+}
+
+// itemTrendQuery counts the results of a single control or benchmark in each run
+// $1 is the item name and $2 is the item name prefixed with '.' - used to match unqualified names
+func itemTrendQuery() string {
+	statusCount := func(status string) string {
+		return fmt.Sprintf("count(*) filter (where c.status = '%s')", status)
+	}
+	return fmt.Sprintf(`select r.run_id, r.target, r.start_time, %s, %s, %s, %s, %s, null::double precision
+from %s r
+join %s c on c.run_id = r.run_id
+where c.control_id = $1
+or right(c.control_id, length($2)) = $2
+or exists (select 1 from unnest(c.benchmarks) b where b = $1 or right(b, length($2)) = $2)
+group by r.run_id, r.target, r.start_time
+order by r.start_time desc
+limit $3`,
+		statusCount(constants.ControlOk),
+		statusCount(constants.ControlAlarm),
+		statusCount(constants.ControlInfo),
+		statusCount(constants.ControlSkip),
+		statusCount(constants.ControlError),
+		historyTable(constants.CheckHistoryRunTable),
+		historyTable(constants.CheckHistoryResultTable),
+	)
+}
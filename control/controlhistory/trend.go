@@ -0,0 +1,28 @@
+package controlhistory
+
+import (
+	"time"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+)
+
+// TrendRow is the status summary of a single check history run
+type TrendRow struct {
+	RunId     string
+	Target    string
+	StartTime time.Time
+	Summary   controlstatus.StatusSummary
+	// the weighted compliance score of the run (only set for whole runs)
+	Score *float64
+	// the change in the number of alarms since the previous run (nil for the first run)
+	AlarmChange *int
+}
+
+// calculateAlarmChanges sets the alarm change of each row relative to the preceding row
+// rows must be ordered oldest first
+func calculateAlarmChanges(rows []*TrendRow) {
+	for i := 1; i < len(rows); i++ {
+		change := rows[i].Summary.Alarm - rows[i-1].Summary.Alarm
+		rows[i].AlarmChange = &change
+	}
+}
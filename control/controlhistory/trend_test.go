@@ -0,0 +1,26 @@
+package controlhistory
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlstatus"
+)
+
+func TestCalculateAlarmChanges(t *testing.T) {
+	rows := []*TrendRow{
+		{RunId: "r1", Summary: controlstatus.StatusSummary{Alarm: 5}},
+		{RunId: "r2", Summary: controlstatus.StatusSummary{Alarm: 3}},
+		{RunId: "r3", Summary: controlstatus.StatusSummary{Alarm: 7}},
+	}
+	calculateAlarmChanges(rows)
+
+	if rows[0].AlarmChange != nil {
+		t.Errorf("expected no alarm change for the first run, got %d", *rows[0].AlarmChange)
+	}
+	expected := map[string]int{"r2": -2, "r3": 4}
+	for _, row := range rows[1:] {
+		if row.AlarmChange == nil || *row.AlarmChange != expected[row.RunId] {
+			t.Errorf("Test: '%s'' FAILED : expected alarm change %d, got %v", row.RunId, expected[row.RunId], row.AlarmChange)
+		}
+	}
+}
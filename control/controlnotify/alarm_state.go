@@ -0,0 +1,90 @@
+package controlnotify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controlexecute"
+)
+
+// AlarmState records the alarmed results of the previous check run of an argument,
+// so that the 'alarm' event is only raised for controls and resources which have newly alarmed
+type AlarmState struct {
+	// the keys of the control/resource pairs which alarmed or errored in the previous run
+	Alarms map[string]bool `json:"alarms"`
+	path   string
+}
+
+// LoadAlarmState loads the alarm state of the previous run of the given argument from the given directory
+// if there is no previous state, or it cannot be read, an empty state is returned, so all alarms are treated as new
+func LoadAlarmState(dir, arg string) *AlarmState {
+	hash := sha256.Sum256([]byte(arg))
+	state := &AlarmState{
+		Alarms: make(map[string]bool),
+		path:   filepath.Join(dir, hex.EncodeToString(hash[:])+".json"),
+	}
+	data, err := os.ReadFile(state.path)
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(data, state); err != nil || state.Alarms == nil {
+		log.Printf("[WARN] ignoring invalid alarm state '%s'", state.path)
+		state.Alarms = make(map[string]bool)
+	}
+	return state
+}
+
+// NewAlarms returns the number of results of each control which alarmed or errored in the execution tree,
+// but not in the previous run, keyed by control id
+func (s *AlarmState) NewAlarms(tree *controlexecute.ExecutionTree) map[string]int {
+	res := make(map[string]int)
+	for _, run := range tree.ControlRuns {
+		for _, key := range alarmKeys(run) {
+			if !s.Alarms[key] {
+				res[run.ControlId]++
+			}
+		}
+	}
+	return res
+}
+
+// Save replaces the state with the alarmed results of the execution tree and persists it,
+// so an alarm which is resolved and later recurs is treated as new
+func (s *AlarmState) Save(tree *controlexecute.ExecutionTree) error {
+	s.Alarms = make(map[string]bool)
+	for _, run := range tree.ControlRuns {
+		for _, key := range alarmKeys(run) {
+			s.Alarms[key] = true
+		}
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// the keys of the results of the control run which alarmed or errored
+// a control run which failed has a single key for the control, with no resource
+func alarmKeys(run *controlexecute.ControlRun) []string {
+	if len(run.Rows) == 0 && run.GetError() != nil {
+		return []string{alarmKey(run.ControlId, "")}
+	}
+	var res []string
+	for _, row := range run.Rows {
+		if row.Status == constants.ControlAlarm || row.Status == constants.ControlError {
+			res = append(res, alarmKey(run.ControlId, row.Resource))
+		}
+	}
+	return res
+}
+
+func alarmKey(controlId, resource string) string {
+	return fmt.Sprintf("%s/%s", controlId, resource)
+}
@@ -0,0 +1,44 @@
+package controlnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// CommandNotifier executes a local command, passing the JSON payload on stdin
+// summary values are also passed as STEAMPIPE_CHECK_* environment variables
+type CommandNotifier struct {
+	config *modconfig.Notifier
+}
+
+func (n *CommandNotifier) Notify(ctx context.Context, payload *Payload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, typehelpers.SafeString(n.config.Command), n.config.Args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("STEAMPIPE_CHECK_EVENT=%s", payload.Event),
+		fmt.Sprintf("STEAMPIPE_CHECK_TITLE=%s", payload.Title),
+		fmt.Sprintf("STEAMPIPE_CHECK_OK=%d", payload.Status.Ok),
+		fmt.Sprintf("STEAMPIPE_CHECK_SKIP=%d", payload.Status.Skip),
+		fmt.Sprintf("STEAMPIPE_CHECK_INFO=%d", payload.Status.Info),
+		fmt.Sprintf("STEAMPIPE_CHECK_ALARM=%d", payload.Status.Alarm),
+		fmt.Sprintf("STEAMPIPE_CHECK_ERROR=%d", payload.Status.Error),
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %s", err.Error(), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
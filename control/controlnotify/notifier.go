@@ -0,0 +1,69 @@
+package controlnotify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+const notifyTimeout = 30 * time.Second
+
+// Notifier sends a check run payload to an external destination
+type Notifier interface {
+	Notify(ctx context.Context, payload *Payload) error
+}
+
+// NewNotifier creates a Notifier from the notifier config
+func NewNotifier(config *modconfig.Notifier) (Notifier, error) {
+	switch config.Type {
+	case modconfig.NotifierTypeWebhook:
+		return &WebhookNotifier{config: config}, nil
+	case modconfig.NotifierTypeSlack:
+		return &SlackNotifier{config: config}, nil
+	case modconfig.NotifierTypeCommand:
+		return &CommandNotifier{config: config}, nil
+	}
+	return nil, fmt.Errorf("notifier '%s' has unsupported type '%s'", config.Name, config.Type)
+}
+
+// NotifyAll sends notifications for the completed execution tree to all notifiers configured for the raised events
+// - 'complete' is always raised
+// - 'alarm' is raised if any control or resource has alarmed or errored which did not in the previous run,
+// as recorded in the alarm state
+func NotifyAll(ctx context.Context, configs []*modconfig.Notifier, arg string, tree *controlexecute.ExecutionTree, alarmState *AlarmState) []error {
+	events := []string{modconfig.NotifierEventComplete}
+	newAlarms := alarmState.NewAlarms(tree)
+	if len(newAlarms) > 0 {
+		events = append(events, modconfig.NotifierEventAlarm)
+	}
+
+	var errors []error
+	for _, config := range configs {
+		for _, event := range events {
+			if !config.ShouldNotify(event) {
+				continue
+			}
+			notifier, err := NewNotifier(config)
+			if err != nil {
+				errors = append(errors, err)
+				continue
+			}
+			log.Printf("[TRACE] sending '%s' notification to notifier '%s'", event, config.Name)
+			notifyCtx, cancel := context.WithTimeout(ctx, notifyTimeout)
+			payload := NewPayload(event, arg, tree)
+			if event == modconfig.NotifierEventAlarm {
+				payload = NewAlarmPayload(arg, tree, newAlarms)
+			}
+			err = notifier.Notify(notifyCtx, payload)
+			cancel()
+			if err != nil {
+				errors = append(errors, fmt.Errorf("notifier '%s' failed: %s", config.Name, err.Error()))
+			}
+		}
+	}
+	return errors
+}
@@ -0,0 +1,123 @@
+package controlnotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func testExecutionTree() *controlexecute.ExecutionTree {
+	run := &controlexecute.ControlRun{
+		ControlId: "control.c1",
+		Title:     "Control 1",
+		Severity:  "high",
+		Summary:   &controlstatus.StatusSummary{Alarm: 2, Ok: 1},
+		Rows: []*controlexecute.ResultRow{
+			{Resource: "r1", Status: "alarm"},
+			{Resource: "r2", Status: "alarm"},
+			{Resource: "r3", Status: "ok"},
+		},
+	}
+	root := controlexecute.MergeResultGroups(&controlexecute.ResultGroup{
+		GroupId: controlexecute.RootResultGroupName,
+		Groups:  []*controlexecute.ResultGroup{{GroupId: "benchmark.b1", Title: "Benchmark 1", ControlRuns: []*controlexecute.ControlRun{run}}},
+	})
+	return &controlexecute.ExecutionTree{Root: root, ControlRuns: []*controlexecute.ControlRun{run}}
+}
+
+func TestNotifyAll(t *testing.T) {
+	var received []*Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload Payload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, &payload)
+	}))
+	defer server.Close()
+
+	notifiers := []*modconfig.Notifier{
+		{Name: "all", Type: modconfig.NotifierTypeWebhook, Url: &server.URL, Headers: map[string]string{"X-Token": "secret"}, On: []string{"complete", "alarm"}},
+		{Name: "unauthorized", Type: modconfig.NotifierTypeWebhook, Url: &server.URL},
+	}
+
+	alarmState := LoadAlarmState(t.TempDir(), "benchmark.b1")
+	errors := NotifyAll(context.Background(), notifiers, "benchmark.b1", testExecutionTree(), alarmState)
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "unauthorized") {
+		t.Errorf("expected a single error from notifier 'unauthorized', got %v", errors)
+	}
+	if len(received) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(received))
+	}
+	if received[0].Event != "complete" || received[1].Event != "alarm" {
+		t.Errorf("unexpected events '%s', '%s'", received[0].Event, received[1].Event)
+	}
+	if received[1].Title != "Benchmark 1" || received[1].Status.Alarm != 2 || len(received[1].AlarmedControls) != 1 || received[1].AlarmedControls[0].NewAlarms != 2 {
+		t.Errorf("unexpected payload %+v", received[1])
+	}
+}
+
+func TestNotifyAllNewAlarmsOnly(t *testing.T) {
+	var received []*Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload Payload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, &payload)
+	}))
+	defer server.Close()
+
+	notifiers := []*modconfig.Notifier{{Name: "alarm", Type: modconfig.NotifierTypeWebhook, Url: &server.URL, On: []string{"alarm"}}}
+	dir := t.TempDir()
+
+	// the first run alarms, so raises an alarm
+	tree := testExecutionTree()
+	alarmState := LoadAlarmState(dir, "benchmark.b1")
+	if errors := NotifyAll(context.Background(), notifiers, "benchmark.b1", tree, alarmState); len(errors) > 0 {
+		t.Fatalf("unexpected errors %v", errors)
+	}
+	if err := alarmState.Save(tree); err != nil {
+		t.Fatal(err)
+	}
+
+	// the same alarms in the next run do not raise an alarm
+	tree = testExecutionTree()
+	alarmState = LoadAlarmState(dir, "benchmark.b1")
+	NotifyAll(context.Background(), notifiers, "benchmark.b1", tree, alarmState)
+	if len(received) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(received))
+	}
+
+	// a new alarming resource raises an alarm, for that resource only
+	tree = testExecutionTree()
+	tree.ControlRuns[0].Rows[2].Status = "alarm"
+	NotifyAll(context.Background(), notifiers, "benchmark.b1", tree, alarmState)
+	if len(received) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(received))
+	}
+	if len(received[1].AlarmedControls) != 1 || received[1].AlarmedControls[0].NewAlarms != 1 {
+		t.Errorf("unexpected payload %+v", received[1])
+	}
+}
+
+func TestBuildSlackText(t *testing.T) {
+	text := buildSlackText(NewPayload("alarm", "benchmark.b1", testExecutionTree()))
+	expected := "*Steampipe check complete: Benchmark 1*\nOK: 1  Skip: 0  Info: 0  Alarm: 2  Error: 0  Total: 3\n• Control 1 [HIGH]: 2 alarm, 0 error"
+	if text != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, text)
+	}
+}
@@ -0,0 +1,79 @@
+package controlnotify
+
+import (
+	"time"
+
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/control/controlstatus"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// Payload is the data sent to notifiers when a check run completes
+type Payload struct {
+	Event     string                                 `json:"event"`
+	Arg       string                                 `json:"arg"`
+	Title     string                                 `json:"title"`
+	StartTime time.Time                              `json:"start_time"`
+	EndTime   time.Time                              `json:"end_time"`
+	Status    controlstatus.StatusSummary            `json:"status"`
+	Severity  map[string]controlstatus.StatusSummary `json:"severity,omitempty"`
+	// the controls which raised alarms - for 'alarm' notifications, only the controls with new alarms
+	AlarmedControls []*AlarmedControl `json:"alarmed_controls,omitempty"`
+}
+
+// AlarmedControl is a summary of a control which raised alarms
+type AlarmedControl struct {
+	ControlId string `json:"control_id"`
+	Title     string `json:"title"`
+	Severity  string `json:"severity,omitempty"`
+	Alarm     int    `json:"alarm"`
+	Error     int    `json:"error"`
+	// the number of results which alarmed or errored which did not in the previous run ('alarm' notifications only)
+	NewAlarms int `json:"new_alarms,omitempty"`
+}
+
+// NewPayload builds a notification payload from the summary of a completed execution tree
+func NewPayload(event, arg string, tree *controlexecute.ExecutionTree) *Payload {
+	summary := tree.Root.Summary
+	payload := &Payload{
+		Event:     event,
+		Arg:       arg,
+		Title:     arg,
+		StartTime: tree.StartTime,
+		EndTime:   tree.EndTime,
+		Status:    summary.Status,
+		Severity:  summary.Severity,
+	}
+	// if the root contains a single benchmark, use its title
+	if len(tree.Root.Groups) == 1 && tree.Root.Groups[0].Title != "" {
+		payload.Title = tree.Root.Groups[0].Title
+	}
+	for _, run := range tree.ControlRuns {
+		if run.Summary.Alarm == 0 && run.Summary.Error == 0 {
+			continue
+		}
+		payload.AlarmedControls = append(payload.AlarmedControls, &AlarmedControl{
+			ControlId: run.ControlId,
+			Title:     run.Title,
+			Severity:  run.Severity,
+			Alarm:     run.Summary.Alarm,
+			Error:     run.Summary.Error,
+		})
+	}
+	return payload
+}
+
+// NewAlarmPayload builds the payload of an 'alarm' notification, listing only the controls with new alarms,
+// given the number of new alarms of each control, keyed by control id
+func NewAlarmPayload(arg string, tree *controlexecute.ExecutionTree, newAlarms map[string]int) *Payload {
+	payload := NewPayload(modconfig.NotifierEventAlarm, arg, tree)
+	var alarmedControls []*AlarmedControl
+	for _, alarmedControl := range payload.AlarmedControls {
+		if count := newAlarms[alarmedControl.ControlId]; count > 0 {
+			alarmedControl.NewAlarms = count
+			alarmedControls = append(alarmedControls, alarmedControl)
+		}
+	}
+	payload.AlarmedControls = alarmedControls
+	return payload
+}
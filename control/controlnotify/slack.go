@@ -0,0 +1,46 @@
+package controlnotify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// the maximum number of alarmed controls listed in a slack message
+const maxSlackAlarmedControls = 10
+
+// SlackNotifier posts a message to a Slack-compatible incoming webhook
+type SlackNotifier struct {
+	config *modconfig.Notifier
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, payload *Payload) error {
+	return postJSON(ctx, typehelpers.SafeString(n.config.Url), n.config.Headers, &slackMessage{Text: buildSlackText(payload)})
+}
+
+func buildSlackText(payload *Payload) string {
+	s := payload.Status
+	lines := []string{
+		fmt.Sprintf("*Steampipe check complete: %s*", payload.Title),
+		fmt.Sprintf("OK: %d  Skip: %d  Info: %d  Alarm: %d  Error: %d  Total: %d", s.Ok, s.Skip, s.Info, s.Alarm, s.Error, s.TotalCount()),
+	}
+	for i, c := range payload.AlarmedControls {
+		if i == maxSlackAlarmedControls {
+			lines = append(lines, fmt.Sprintf("...and %d more", len(payload.AlarmedControls)-maxSlackAlarmedControls))
+			break
+		}
+		severity := ""
+		if c.Severity != "" {
+			severity = fmt.Sprintf(" [%s]", strings.ToUpper(c.Severity))
+		}
+		lines = append(lines, fmt.Sprintf("• %s%s: %d alarm, %d error", c.Title, severity, c.Alarm, c.Error))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,51 @@
+package controlnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-cleanhttp"
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// WebhookNotifier posts the JSON payload to a url
+type WebhookNotifier struct {
+	config *modconfig.Notifier
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, payload *Payload) error {
+	return postJSON(ctx, typehelpers.SafeString(n.config.Url), n.config.Headers, payload)
+}
+
+func postJSON(ctx context.Context, url string, headers map[string]string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := cleanhttp.DefaultClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %s", url, resp.Status)
+	}
+	return nil
+}
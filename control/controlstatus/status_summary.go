@@ -18,15 +18,15 @@ type StatusSummary struct {
 	Error int `json:"error"`
 }
 
-func (s *StatusSummary) PassedCount() int {
+func (s StatusSummary) PassedCount() int {
 	return s.Ok + s.Info
 }
 
-func (s *StatusSummary) FailedCount() int {
+func (s StatusSummary) FailedCount() int {
 	return s.Alarm + s.Error
 }
 
-func (s *StatusSummary) TotalCount() int {
+func (s StatusSummary) TotalCount() int {
 	return s.Alarm + s.Ok + s.Info + s.Skip + s.Error
 }
 
@@ -2,7 +2,6 @@ package control
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/spf13/viper"
 	"github.com/turbot/steampipe/cmdconfig"
@@ -56,7 +55,7 @@ func NewInitData(ctx context.Context, w *workspace.Workspace) *InitData {
 	// set cloud metadata (may be nil)
 	initData.Workspace.CloudMetadata = cloudMetadata
 	// set color schema
-	err = initialiseColorScheme()
+	err = controldisplay.InitialiseColorScheme()
 	if err != nil {
 		initData.Result.Error = err
 		return initData
@@ -115,21 +114,3 @@ func (i InitData) Cleanup(ctx context.Context) {
 		i.Client.Close(ctx)
 	}
 }
-
-func initialiseColorScheme() error {
-	theme := viper.GetString(constants.ArgTheme)
-	if !viper.GetBool(constants.ConfigKeyIsTerminalTTY) {
-		// enforce plain output for non-terminals
-		theme = "plain"
-	}
-	themeDef, ok := controldisplay.ColorSchemes[theme]
-	if !ok {
-		return fmt.Errorf("invalid theme '%s'", theme)
-	}
-	scheme, err := controldisplay.NewControlColorScheme(themeDef)
-	if err != nil {
-		return err
-	}
-	controldisplay.ControlColors = scheme
-	return nil
-}
@@ -0,0 +1,69 @@
+package dashboarddisplay
+
+import (
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/control/controldisplay"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// card is the key figure shown by a card panel
+type card struct {
+	label    string
+	value    string
+	cardType string
+}
+
+// newCard returns the label, value and type of a card run
+// if the card query returns 'label', 'value' or 'type' columns, these override the static card properties,
+// otherwise the name of the first column is the label and its value in the first row is the value
+func newCard(run *dashboardexecute.LeafRun) *card {
+	res := &card{}
+	if node, ok := run.DashboardNode.(*modconfig.DashboardCard); ok {
+		res.label = typehelpers.SafeString(node.Label)
+		res.value = typehelpers.SafeString(node.Value)
+		res.cardType = typehelpers.SafeString(node.Type)
+	}
+
+	if data := run.Data; data != nil && len(data.Columns) > 0 && len(data.Rows) > 0 {
+		if hasColumn(data, "value") {
+			res.label = leafDataValue(data, "label", res.label)
+			res.value = leafDataValue(data, "value", res.value)
+			res.cardType = leafDataValue(data, "type", res.cardType)
+		} else {
+			res.label = data.Columns[0].Name
+			res.value = leafDataString(data.Rows[0][0], data.Columns[0].DataType)
+		}
+	}
+
+	if res.label == "" {
+		res.label = run.Title
+	}
+	if run.ErrorString != "" {
+		res.value = "error"
+		res.cardType = "alert"
+	}
+	return res
+}
+
+// displayValue returns the card value, colored according to the card type
+func (c *card) displayValue() interface{} {
+	switch c.cardType {
+	case "alert":
+		return controldisplay.ControlColors.StatusAlarm(c.value)
+	case "ok":
+		return controldisplay.ControlColors.StatusOK(c.value)
+	case "info":
+		return controldisplay.ControlColors.StatusInfo(c.value)
+	}
+	return c.value
+}
+
+func hasColumn(data *dashboardexecute.LeafData, columnName string) bool {
+	for _, c := range data.Columns {
+		if c.Name == columnName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package dashboarddisplay
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+type newCardTest struct {
+	run      *dashboardexecute.LeafRun
+	expected *card
+}
+
+func newTestCardRun(label, value string, data *dashboardexecute.LeafData) *dashboardexecute.LeafRun {
+	node := &modconfig.DashboardCard{}
+	if label != "" {
+		node.Label = &label
+	}
+	if value != "" {
+		node.Value = &value
+	}
+	return &dashboardexecute.LeafRun{Title: "Card Title", DashboardNode: node, Data: data}
+}
+
+var testCasesNewCard = map[string]newCardTest{
+	"static": {
+		run:      newTestCardRun("Regions", "17", nil),
+		expected: &card{label: "Regions", value: "17"},
+	},
+	"no label": {
+		run:      newTestCardRun("", "17", nil),
+		expected: &card{label: "Card Title", value: "17"},
+	},
+	"first column": {
+		run: newTestCardRun("", "", &dashboardexecute.LeafData{
+			Columns: []*dashboardexecute.LeafDataColumnType{{Name: "Public Buckets", DataType: "INT8"}},
+			Rows:    [][]interface{}{{int64(3)}},
+		}),
+		expected: &card{label: "Public Buckets", value: "3"},
+	},
+	"label value and type columns": {
+		run: newTestCardRun("Buckets", "", &dashboardexecute.LeafData{
+			Columns: []*dashboardexecute.LeafDataColumnType{{Name: "label", DataType: "TEXT"}, {Name: "value", DataType: "INT8"}, {Name: "type", DataType: "TEXT"}},
+			Rows:    [][]interface{}{{"Public Buckets", int64(3), "alert"}},
+		}),
+		expected: &card{label: "Public Buckets", value: "3", cardType: "alert"},
+	},
+	"value column": {
+		run: newTestCardRun("Buckets", "", &dashboardexecute.LeafData{
+			Columns: []*dashboardexecute.LeafDataColumnType{{Name: "value", DataType: "INT8"}},
+			Rows:    [][]interface{}{{int64(3)}},
+		}),
+		expected: &card{label: "Buckets", value: "3"},
+	},
+	"error": {
+		run:      &dashboardexecute.LeafRun{Title: "Buckets", DashboardNode: &modconfig.DashboardCard{}, ErrorString: "query failed"},
+		expected: &card{label: "Buckets", value: "error", cardType: "alert"},
+	},
+}
+
+func TestNewCard(t *testing.T) {
+	for name, test := range testCasesNewCard {
+		if res := newCard(test.run); !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, res)
+		}
+	}
+}
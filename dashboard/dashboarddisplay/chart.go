@@ -0,0 +1,336 @@
+package dashboarddisplay
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/display"
+)
+
+// the chart types which are rendered as line charts - all other chart types are rendered as bar charts
+var lineChartTypes = []string{"line", "area"}
+
+// the chart types which show the share of the total of each value
+var shareChartTypes = []string{"pie", "donut"}
+
+// the markers used to draw each series of a chart
+var seriesMarkers = []string{"#", "=", "*", "+", "o", "x"}
+
+// the number of rows of a line chart plot
+const lineChartHeight = 10
+
+// the minimum width of the bars of a bar chart
+const minBarWidth = 10
+
+// the database types which hold numeric values
+var numericDataTypes = []string{"INT2", "INT4", "INT8", "FLOAT4", "FLOAT8", "NUMERIC"}
+
+type chartSeries struct {
+	name   string
+	values []float64
+}
+
+// chartData is the data of a chart, as a list of categories (the x axis) and the series values for each category
+type chartData struct {
+	categories []string
+	series     []*chartSeries
+}
+
+// newChartData builds chart data from the result of a chart query
+// the first column holds the categories
+// if the data is crosstabbed, the second column holds the series names and the third the values,
+// otherwise each subsequent column is a series
+func newChartData(data *dashboardexecute.LeafData, transform string) (*chartData, error) {
+	if len(data.Columns) < 2 {
+		return nil, fmt.Errorf("chart data must have at least 2 columns")
+	}
+	if isCrosstab(data, transform) {
+		return newCrosstabChartData(data)
+	}
+
+	res := &chartData{categories: make([]string, len(data.Rows))}
+	for _, c := range data.Columns[1:] {
+		res.series = append(res.series, &chartSeries{name: c.Name, values: make([]float64, len(data.Rows))})
+	}
+	for rowIdx, row := range data.Rows {
+		res.categories[rowIdx] = chartCategory(row[0], data.Columns[0].DataType)
+		for seriesIdx, s := range res.series {
+			s.values[rowIdx], _ = toFloat(row[seriesIdx+1])
+		}
+	}
+	return res, nil
+}
+
+// build chart data from rows of category, series name and value
+// categories and series are ordered by their first appearance in the data
+func newCrosstabChartData(data *dashboardexecute.LeafData) (*chartData, error) {
+	if len(data.Columns) != 3 {
+		return nil, fmt.Errorf("crosstab chart data must have 3 columns")
+	}
+	res := &chartData{}
+	categoryIdx := make(map[string]int)
+	seriesMap := make(map[string]*chartSeries)
+	for _, row := range data.Rows {
+		category := chartCategory(row[0], data.Columns[0].DataType)
+		if _, ok := categoryIdx[category]; !ok {
+			categoryIdx[category] = len(res.categories)
+			res.categories = append(res.categories, category)
+			for _, s := range res.series {
+				s.values = append(s.values, 0)
+			}
+		}
+		seriesName := chartCategory(row[1], data.Columns[1].DataType)
+		s, ok := seriesMap[seriesName]
+		if !ok {
+			s = &chartSeries{name: seriesName, values: make([]float64, len(res.categories))}
+			seriesMap[seriesName] = s
+			res.series = append(res.series, s)
+		}
+		s.values[categoryIdx[category]], _ = toFloat(row[2])
+	}
+	return res, nil
+}
+
+// the data is crosstabbed if the chart transform is 'crosstab', or if the transform is automatic
+// and the data has 3 columns, the second of which is not numeric
+func isCrosstab(data *dashboardexecute.LeafData, transform string) bool {
+	switch transform {
+	case "crosstab":
+		return true
+	case "", "auto":
+		return len(data.Columns) == 3 && !helpers.StringSliceContains(numericDataTypes, data.Columns[1].DataType)
+	}
+	return false
+}
+
+func chartCategory(val interface{}, dataType string) string {
+	category, err := display.ColumnValueAsStringForType(val, dataType)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return category
+}
+
+// convert a query result value to a float - values which are not numeric convert to zero
+func toFloat(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case []byte:
+		f, err := strconv.ParseFloat(string(v), 64)
+		return f, err == nil
+	case nil:
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(fmt.Sprintf("%v", val), 64)
+	return f, err == nil
+}
+
+func formatChartValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// renderBarChart renders a horizontal bar for each category and series, e.g.
+//
+//	us-east-1 | ############ 12
+//	us-east-2 | ###### 6
+//
+// if showShare is set, the share of the total of the series is shown after each value
+func renderBarChart(data *chartData, width int, showShare bool) string {
+	if len(data.categories) == 0 {
+		return "No data\n"
+	}
+
+	labelWidth := 0
+	for _, c := range data.categories {
+		labelWidth = maxInt(labelWidth, utf8.RuneCountInString(c))
+	}
+	// do not let the labels take up more than a third of the width
+	labelWidth = minInt(labelWidth, maxInt(width/3, 1))
+
+	maxValue := 0.0
+	valueWidth := 0
+	totals := make([]float64, len(data.series))
+	valueStrings := make([][]string, len(data.series))
+	for seriesIdx, s := range data.series {
+		for _, v := range s.values {
+			maxValue = math.Max(maxValue, v)
+			totals[seriesIdx] += v
+		}
+	}
+	for seriesIdx, s := range data.series {
+		valueStrings[seriesIdx] = make([]string, len(s.values))
+		for valueIdx, v := range s.values {
+			valueString := formatChartValue(v)
+			if showShare && totals[seriesIdx] != 0 {
+				valueString = fmt.Sprintf("%s (%.1f%%)", valueString, v*100/totals[seriesIdx])
+			}
+			valueStrings[seriesIdx][valueIdx] = valueString
+			valueWidth = maxInt(valueWidth, len(valueString))
+		}
+	}
+
+	// allow for the separator and the space before the value
+	barWidth := maxInt(width-labelWidth-valueWidth-4, minBarWidth)
+
+	var b strings.Builder
+	for categoryIdx, category := range data.categories {
+		for seriesIdx, s := range data.series {
+			label := ""
+			if seriesIdx == 0 {
+				label = truncate(category, labelWidth)
+			}
+			barLength := 0
+			if maxValue > 0 && s.values[categoryIdx] > 0 {
+				barLength = int(math.Round(s.values[categoryIdx] / maxValue * float64(barWidth)))
+			}
+			fmt.Fprintf(&b, "%s | %s %s\n",
+				padRight(label, labelWidth),
+				strings.Repeat(seriesMarker(seriesIdx), barLength),
+				valueStrings[seriesIdx][categoryIdx])
+		}
+	}
+	b.WriteString(renderLegend(data))
+	return b.String()
+}
+
+// renderLineChart plots each series as a line of markers, with the range of values on the y axis
+// and the first and last categories on the x axis
+func renderLineChart(data *chartData, width int) string {
+	if len(data.categories) == 0 {
+		return "No data\n"
+	}
+
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for _, s := range data.series {
+		for _, v := range s.values {
+			minValue = math.Min(minValue, v)
+			maxValue = math.Max(maxValue, v)
+		}
+	}
+	if minValue == maxValue {
+		maxValue = minValue + 1
+	}
+	minLabel, maxLabel := formatChartValue(minValue), formatChartValue(maxValue)
+	yLabelWidth := maxInt(len(minLabel), len(maxLabel))
+
+	categoryCount := len(data.categories)
+	// spread the points out, up to the available width
+	plotWidth := minInt(maxInt(width-yLabelWidth-2, minBarWidth), maxInt(categoryCount*4, minBarWidth))
+
+	plot := make([][]string, lineChartHeight)
+	for i := range plot {
+		plot[i] = strings.Split(strings.Repeat(" ", plotWidth), "")
+	}
+	for seriesIdx, s := range data.series {
+		for valueIdx, v := range s.values {
+			x := 0
+			if categoryCount > 1 {
+				x = valueIdx * (plotWidth - 1) / (categoryCount - 1)
+			}
+			y := int(math.Round((v - minValue) / (maxValue - minValue) * float64(lineChartHeight-1)))
+			plot[lineChartHeight-1-y][x] = seriesMarker(seriesIdx)
+		}
+	}
+
+	var b strings.Builder
+	for rowIdx, row := range plot {
+		yLabel := ""
+		switch rowIdx {
+		case 0:
+			yLabel = maxLabel
+		case lineChartHeight - 1:
+			yLabel = minLabel
+		}
+		fmt.Fprintf(&b, "%s |%s\n", padLeft(yLabel, yLabelWidth), strings.TrimRight(strings.Join(row, ""), " "))
+	}
+	fmt.Fprintf(&b, "%s +%s\n", strings.Repeat(" ", yLabelWidth), strings.Repeat("-", plotWidth))
+
+	// show the first and last categories at either end of the x axis
+	xLabels := truncate(data.categories[0], plotWidth)
+	if categoryCount > 1 {
+		last := data.categories[categoryCount-1]
+		if gap := plotWidth - utf8.RuneCountInString(xLabels) - utf8.RuneCountInString(last); gap > 0 {
+			xLabels += strings.Repeat(" ", gap) + last
+		}
+	}
+	fmt.Fprintf(&b, "%s  %s\n", strings.Repeat(" ", yLabelWidth), xLabels)
+	b.WriteString(renderLegend(data))
+	return b.String()
+}
+
+// a legend is only shown for charts with more than one series
+func renderLegend(data *chartData) string {
+	if len(data.series) < 2 {
+		return ""
+	}
+	var items []string
+	for seriesIdx, s := range data.series {
+		items = append(items, fmt.Sprintf("%s %s", seriesMarker(seriesIdx), s.name))
+	}
+	return fmt.Sprintf("\n%s\n", strings.Join(items, "  "))
+}
+
+func seriesMarker(seriesIdx int) string {
+	return seriesMarkers[seriesIdx%len(seriesMarkers)]
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string([]rune(s)[:width])
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
+
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", maxInt(width-utf8.RuneCountInString(s), 0))
+}
+
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", maxInt(width-utf8.RuneCountInString(s), 0)) + s
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,174 @@
+package dashboarddisplay
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+)
+
+type chartDataTest struct {
+	data      *dashboardexecute.LeafData
+	transform string
+	expected  *chartData
+}
+
+var testCasesChartData = map[string]chartDataTest{
+	"single series": {
+		data: &dashboardexecute.LeafData{
+			Columns: []*dashboardexecute.LeafDataColumnType{{Name: "region", DataType: "TEXT"}, {Name: "count", DataType: "INT8"}},
+			Rows:    [][]interface{}{{"us-east-1", int64(12)}, {"us-east-2", int64(6)}},
+		},
+		expected: &chartData{
+			categories: []string{"us-east-1", "us-east-2"},
+			series:     []*chartSeries{{name: "count", values: []float64{12, 6}}},
+		},
+	},
+	"multiple series": {
+		data: &dashboardexecute.LeafData{
+			Columns: []*dashboardexecute.LeafDataColumnType{{Name: "region", DataType: "TEXT"}, {Name: "running", DataType: "INT8"}, {Name: "stopped", DataType: "NUMERIC"}},
+			Rows:    [][]interface{}{{"us-east-1", int64(12), "1.5"}, {"us-east-2", int64(6), nil}},
+		},
+		expected: &chartData{
+			categories: []string{"us-east-1", "us-east-2"},
+			series: []*chartSeries{
+				{name: "running", values: []float64{12, 6}},
+				{name: "stopped", values: []float64{1.5, 0}},
+			},
+		},
+	},
+	"automatic crosstab": {
+		data: &dashboardexecute.LeafData{
+			Columns: []*dashboardexecute.LeafDataColumnType{{Name: "region", DataType: "TEXT"}, {Name: "state", DataType: "TEXT"}, {Name: "count", DataType: "INT8"}},
+			Rows: [][]interface{}{
+				{"us-east-1", "running", int64(12)},
+				{"us-east-1", "stopped", int64(2)},
+				{"us-east-2", "stopped", int64(6)},
+			},
+		},
+		expected: &chartData{
+			categories: []string{"us-east-1", "us-east-2"},
+			series: []*chartSeries{
+				{name: "running", values: []float64{12, 0}},
+				{name: "stopped", values: []float64{2, 6}},
+			},
+		},
+	},
+	"crosstab disabled": {
+		data: &dashboardexecute.LeafData{
+			Columns: []*dashboardexecute.LeafDataColumnType{{Name: "region", DataType: "TEXT"}, {Name: "state", DataType: "TEXT"}, {Name: "count", DataType: "INT8"}},
+			Rows:    [][]interface{}{{"us-east-1", "running", int64(12)}},
+		},
+		transform: "none",
+		expected: &chartData{
+			categories: []string{"us-east-1"},
+			series: []*chartSeries{
+				{name: "state", values: []float64{0}},
+				{name: "count", values: []float64{12}},
+			},
+		},
+	},
+	"single column": {
+		data: &dashboardexecute.LeafData{
+			Columns: []*dashboardexecute.LeafDataColumnType{{Name: "count", DataType: "INT8"}},
+			Rows:    [][]interface{}{{int64(12)}},
+		},
+		expected: nil,
+	},
+}
+
+func TestNewChartData(t *testing.T) {
+	for name, test := range testCasesChartData {
+		res, err := newChartData(test.data, test.transform)
+		if test.expected == nil {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, res)
+		}
+	}
+}
+
+type renderChartTest struct {
+	data     *chartData
+	render   func(*chartData) string
+	expected string
+}
+
+var testCasesRenderChart = map[string]renderChartTest{
+	"bar chart": {
+		data: &chartData{
+			categories: []string{"us-east-1", "us-east-2"},
+			series:     []*chartSeries{{name: "count", values: []float64{20, 10}}},
+		},
+		render: func(data *chartData) string { return renderBarChart(data, 35, false) },
+		expected: `us-east-1 | #################### 20
+us-east-2 | ########## 10
+`,
+	},
+	"bar chart share": {
+		data: &chartData{
+			categories: []string{"a", "b"},
+			series:     []*chartSeries{{name: "count", values: []float64{30, 10}}},
+		},
+		render: func(data *chartData) string { return renderBarChart(data, 10, true) },
+		expected: `a | ########## 30 (75.0%)
+b | ### 10 (25.0%)
+`,
+	},
+	"bar chart multiple series": {
+		data: &chartData{
+			categories: []string{"a"},
+			series: []*chartSeries{
+				{name: "running", values: []float64{10}},
+				{name: "stopped", values: []float64{5}},
+			},
+		},
+		render: func(data *chartData) string { return renderBarChart(data, 10, false) },
+		expected: `a | ########## 10
+  | ===== 5
+
+# running  = stopped
+`,
+	},
+	"line chart": {
+		data: &chartData{
+			categories: []string{"jan", "feb", "mar"},
+			series:     []*chartSeries{{name: "count", values: []float64{0, 9, 4.5}}},
+		},
+		render: func(data *chartData) string { return renderLineChart(data, 80) },
+		expected: `9 |     #
+  |
+  |
+  |
+  |           #
+  |
+  |
+  |
+  |
+0 |#
+  +------------
+   jan      mar
+`,
+	},
+	"no data": {
+		data:     &chartData{},
+		render:   func(data *chartData) string { return renderBarChart(data, 80, false) },
+		expected: "No data\n",
+	},
+}
+
+func TestRenderChart(t *testing.T) {
+	for name, test := range testCasesRenderChart {
+		if res := test.render(test.data); res != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected\n%s\ngot\n%s", name, test.expected, res)
+		}
+	}
+}
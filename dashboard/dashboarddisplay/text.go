@@ -0,0 +1,345 @@
+package dashboarddisplay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/turbot/go-kit/helpers"
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/control/controldisplay"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/display"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// the display value of panels and table columns which are hidden
+const displayNone = "none"
+
+// the width used if the terminal width is not known, e.g. when the output is redirected
+const defaultWidth = 80
+
+// TextRenderer renders a completed dashboard execution as text, for display in a terminal
+// - dashboards and containers with a title are rendered as section headings
+// - cards are rendered as key figures, with consecutive cards shown side by side
+// - tables, flows and hierarchies are rendered as tables of their data
+// - charts are rendered as bar or line charts
+// - benchmarks and controls are rendered as for 'steampipe check'
+// NOTE: controldisplay.ControlColors must be initialised before rendering
+type TextRenderer struct {
+	executionTree *dashboardexecute.DashboardExecutionTree
+	width         int
+}
+
+func NewTextRenderer(executionTree *dashboardexecute.DashboardExecutionTree, width int) *TextRenderer {
+	if width <= 0 {
+		width = defaultWidth
+	}
+	return &TextRenderer{
+		executionTree: executionTree,
+		width:         width,
+	}
+}
+
+// Render renders the execution tree
+func (r *TextRenderer) Render(ctx context.Context) (string, error) {
+	var b strings.Builder
+	if err := r.renderRun(ctx, &b, r.executionTree.Root, 0); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (r *TextRenderer) renderRun(ctx context.Context, b *strings.Builder, run dashboardinterfaces.DashboardNodeRun, depth int) error {
+	switch run := run.(type) {
+	case *dashboardexecute.DashboardRun:
+		title := run.Title
+		if title == "" {
+			title = run.Name
+		}
+		r.renderHeading(b, title, depth)
+		r.renderError(b, run.ErrorString)
+		return r.renderChildren(ctx, b, run.Children, depth+1)
+	case *dashboardexecute.DashboardContainerRun:
+		// containers without a title just group their children
+		if run.Title != "" {
+			r.renderHeading(b, run.Title, depth)
+		}
+		r.renderError(b, run.ErrorString)
+		return r.renderChildren(ctx, b, run.Children, depth+1)
+	case *dashboardexecute.CheckRun:
+		return r.renderCheck(ctx, b, run)
+	case *dashboardexecute.LeafRun:
+		r.renderLeaf(b, run)
+	}
+	return nil
+}
+
+// render the children of a dashboard or container, in order
+// consecutive cards are rendered together
+func (r *TextRenderer) renderChildren(ctx context.Context, b *strings.Builder, children []dashboardinterfaces.DashboardNodeRun, depth int) error {
+	var cards []*dashboardexecute.LeafRun
+	for _, child := range children {
+		if leafRun, ok := child.(*dashboardexecute.LeafRun); ok {
+			if isHidden(leafRun.DashboardNode) {
+				continue
+			}
+			if leafRun.NodeType == modconfig.BlockTypeCard {
+				cards = append(cards, leafRun)
+				continue
+			}
+		}
+		if len(cards) > 0 {
+			r.renderCards(b, cards)
+			cards = nil
+		}
+		if err := r.renderRun(ctx, b, child, depth); err != nil {
+			return err
+		}
+	}
+	if len(cards) > 0 {
+		r.renderCards(b, cards)
+	}
+	return nil
+}
+
+// a top level heading is underlined with '=', all others with '-'
+func (r *TextRenderer) renderHeading(b *strings.Builder, title string, depth int) {
+	underline := "-"
+	if depth == 0 {
+		underline = "="
+	}
+	fmt.Fprintf(b, "\n%s\n%s\n", controldisplay.ControlColors.GroupTitle(title), strings.Repeat(underline, utf8.RuneCountInString(title)))
+}
+
+func (r *TextRenderer) renderError(b *strings.Builder, errorString string) {
+	if errorString != "" {
+		fmt.Fprintf(b, "%s %s\n", controldisplay.ControlColors.StatusError("Error:"), errorString)
+	}
+}
+
+func (r *TextRenderer) renderLeaf(b *strings.Builder, run *dashboardexecute.LeafRun) {
+	b.WriteString("\n")
+	if run.Title != "" {
+		fmt.Fprintf(b, "%s\n", controldisplay.ControlColors.GroupTitle(run.Title))
+	}
+	if run.ErrorString != "" {
+		r.renderError(b, run.ErrorString)
+		return
+	}
+
+	switch node := run.DashboardNode.(type) {
+	case *modconfig.DashboardChart:
+		r.renderChart(b, node, run.Data)
+	case *modconfig.DashboardTable:
+		r.renderTable(b, node, run.Data)
+	case *modconfig.DashboardFlow, *modconfig.DashboardHierarchy:
+		r.renderTable(b, nil, run.Data)
+	case *modconfig.DashboardText:
+		fmt.Fprintf(b, "%s\n", strings.TrimSpace(typehelpers.SafeString(node.Value)))
+	case *modconfig.DashboardImage:
+		src := leafDataValue(run.Data, "src", typehelpers.SafeString(node.Src))
+		alt := leafDataValue(run.Data, "alt", typehelpers.SafeString(node.Alt))
+		fmt.Fprintf(b, "[image: %s]\n", strings.TrimSpace(fmt.Sprintf("%s %s", alt, src)))
+	case *modconfig.DashboardInput:
+		label := typehelpers.SafeString(node.Label)
+		if label == "" {
+			label = node.UnqualifiedName
+		}
+		fmt.Fprintf(b, "%s: %s\n", label, formatInputValue(r.executionTree.GetInputValue(node.UnqualifiedName)))
+	}
+}
+
+// render the cards side by side, as a table with a column for each card,
+// wrapping onto further tables if the cards do not fit in the available width
+func (r *TextRenderer) renderCards(b *strings.Builder, runs []*dashboardexecute.LeafRun) {
+	var labels, values table.Row
+	rowWidth := 0
+	renderRow := func() {
+		t := table.NewWriter()
+		t.SetStyle(table.StyleDefault)
+		t.Style().Format.Header = text.FormatDefault
+		t.AppendHeader(labels)
+		t.AppendRow(values)
+		fmt.Fprintf(b, "\n%s\n", t.Render())
+		labels, values, rowWidth = nil, nil, 0
+	}
+
+	for _, run := range runs {
+		c := newCard(run)
+		// allow for the padding and separator of each column
+		cardWidth := maxInt(utf8.RuneCountInString(c.label), utf8.RuneCountInString(c.value)) + 3
+		if len(labels) > 0 && rowWidth+cardWidth+1 > r.width {
+			renderRow()
+		}
+		labels = append(labels, c.label)
+		values = append(values, c.displayValue())
+		rowWidth += cardWidth
+	}
+	renderRow()
+
+	// the errors of cards do not fit in the table, so show them afterwards
+	for _, run := range runs {
+		if run.ErrorString != "" {
+			fmt.Fprintf(b, "%s: ", newCard(run).label)
+			r.renderError(b, run.ErrorString)
+		}
+	}
+}
+
+func (r *TextRenderer) renderTable(b *strings.Builder, node *modconfig.DashboardTable, data *dashboardexecute.LeafData) {
+	if data == nil || len(data.Rows) == 0 {
+		b.WriteString("No data\n")
+		return
+	}
+
+	// do not show columns which have display set to 'none'
+	var columnIdxs []int
+	for idx, c := range data.Columns {
+		if node != nil {
+			if columnSettings, ok := node.Columns[c.Name]; ok && typehelpers.SafeString(columnSettings.Display) == displayNone {
+				continue
+			}
+		}
+		columnIdxs = append(columnIdxs, idx)
+	}
+
+	rows := make([][]string, len(data.Rows))
+	for rowIdx, row := range data.Rows {
+		rows[rowIdx] = make([]string, len(columnIdxs))
+		for i, columnIdx := range columnIdxs {
+			rows[rowIdx][i] = leafDataString(row[columnIdx], data.Columns[columnIdx].DataType)
+		}
+	}
+
+	// tables of type 'line' show each row as a list of column names and values
+	if node != nil && typehelpers.SafeString(node.Type) == "line" {
+		r.renderLineTable(b, data, columnIdxs, rows)
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleDefault)
+	t.Style().Format.Header = text.FormatDefault
+
+	headers := make(table.Row, len(columnIdxs))
+	colConfigs := make([]table.ColumnConfig, len(columnIdxs))
+	for i, columnIdx := range columnIdxs {
+		headers[i] = data.Columns[columnIdx].Name
+		colConfigs[i] = table.ColumnConfig{
+			Number:   i + 1,
+			WidthMax: constants.MaxColumnWidth,
+		}
+	}
+	t.SetColumnConfigs(colConfigs)
+	t.AppendHeader(headers)
+	for _, row := range rows {
+		rowObj := make(table.Row, len(row))
+		for i, col := range row {
+			rowObj[i] = col
+		}
+		t.AppendRow(rowObj)
+	}
+	fmt.Fprintf(b, "%s\n", t.Render())
+}
+
+func (r *TextRenderer) renderLineTable(b *strings.Builder, data *dashboardexecute.LeafData, columnIdxs []int, rows [][]string) {
+	nameWidth := 0
+	for _, columnIdx := range columnIdxs {
+		nameWidth = maxInt(nameWidth, utf8.RuneCountInString(data.Columns[columnIdx].Name))
+	}
+	for rowIdx, row := range rows {
+		if rowIdx > 0 {
+			b.WriteString("\n")
+		}
+		for i, columnIdx := range columnIdxs {
+			fmt.Fprintf(b, "%s | %s\n", padRight(data.Columns[columnIdx].Name, nameWidth), row[i])
+		}
+	}
+}
+
+func (r *TextRenderer) renderChart(b *strings.Builder, node *modconfig.DashboardChart, data *dashboardexecute.LeafData) {
+	if data == nil {
+		b.WriteString("No data\n")
+		return
+	}
+	chart, err := newChartData(data, typehelpers.SafeString(node.Transform))
+	if err != nil {
+		r.renderError(b, err.Error())
+		return
+	}
+
+	chartType := typehelpers.SafeString(node.Type)
+	if helpers.StringSliceContains(lineChartTypes, chartType) {
+		b.WriteString(renderLineChart(chart, r.width))
+		return
+	}
+	b.WriteString(renderBarChart(chart, r.width, helpers.StringSliceContains(shareChartTypes, chartType)))
+}
+
+// render benchmarks and controls using the 'steampipe check' text formatter
+func (r *TextRenderer) renderCheck(ctx context.Context, b *strings.Builder, run *dashboardexecute.CheckRun) error {
+	if run.ErrorString != "" {
+		b.WriteString("\n")
+		r.renderError(b, run.ErrorString)
+		return nil
+	}
+	if run.ControlExecutionTree == nil {
+		return nil
+	}
+	formatter := &controldisplay.TextFormatter{}
+	reader, err := formatter.Format(ctx, run.ControlExecutionTree)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(b, reader)
+	return err
+}
+
+func isHidden(node modconfig.DashboardLeafNode) bool {
+	return typehelpers.SafeString(node.GetDisplay()) == displayNone
+}
+
+// leafDataString returns the display string of a query result value
+// null values are displayed as for 'steampipe query'
+func leafDataString(val interface{}, dataType string) string {
+	res, err := display.ColumnValueAsStringForType(val, dataType)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return res
+}
+
+// leafDataValue returns the value of the named column of the first row of the data,
+// or the default value if there is no such column
+func leafDataValue(data *dashboardexecute.LeafData, columnName, defaultValue string) string {
+	if data == nil || len(data.Rows) == 0 {
+		return defaultValue
+	}
+	for idx, c := range data.Columns {
+		if c.Name == columnName && data.Rows[0][idx] != nil {
+			return leafDataString(data.Rows[0][idx], c.DataType)
+		}
+	}
+	return defaultValue
+}
+
+func formatInputValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "<not set>"
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, item := range v {
+			values[i] = typehelpers.ToString(item)
+		}
+		return strings.Join(values, ", ")
+	}
+	return typehelpers.ToString(value)
+}
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 	"github.com/turbot/steampipe/db/db_common"
@@ -31,8 +33,16 @@ type DashboardExecutionTree struct {
 	cancel                 context.CancelFunc
 	inputValues            map[string]interface{}
 	id                     string
+
+	// limits the number of queries of this execution which run concurrently
+	parallelismLock *priorityLock
+	// the number of runs created - used to prioritise runs in the order they appear in the dashboard
+	runCount int
 }
 
+// the priority offset of panels - inputs are executed before all panels, as other panels may depend on them
+const panelPriorityOffset = 1 << 20
+
 // NewDashboardExecutionTree creates a result group from a ModTreeItem
 func NewDashboardExecutionTree(rootName string, sessionId string, client db_common.Client, workspace *workspace.Workspace) (*DashboardExecutionTree, error) {
 	// now populate the DashboardExecutionTree
@@ -45,6 +55,7 @@ func NewDashboardExecutionTree(rootName string, sessionId string, client db_comm
 		runComplete:            make(chan dashboardinterfaces.DashboardNodeRun, 1),
 		inputDataSubscriptions: make(map[string]map[*chan bool]struct{}),
 		inputValues:            make(map[string]interface{}),
+		parallelismLock:        newPriorityLock(viper.GetInt(constants.ArgDashboardMaxParallel)),
 	}
 	executionTree.id = fmt.Sprintf("%p", executionTree)
 
@@ -126,6 +137,16 @@ func (e *DashboardExecutionTree) Execute(ctx context.Context) {
 	e.Root.Execute(cancelCtx)
 }
 
+// nextRunPriority returns the priority of the next run created in the tree
+// runs are created in the order they appear in the dashboard, so panels at the top of the page run first
+func (e *DashboardExecutionTree) nextRunPriority(isInput bool) int {
+	e.runCount++
+	if isInput {
+		return e.runCount
+	}
+	return panelPriorityOffset + e.runCount
+}
+
 // GetRunStatus returns the stats of the Root run
 func (e *DashboardExecutionTree) GetRunStatus() dashboardinterfaces.DashboardRunStatus {
 	return e.Root.GetRunStatus()
@@ -202,8 +223,13 @@ func (e *DashboardExecutionTree) unsubscribeToInput(inputName string, depChan *c
 }
 
 func (e *DashboardExecutionTree) Cancel() {
+	// if we have completed, cancel any panels which are being re-run
+	if e.GetRunStatus() != dashboardinterfaces.DashboardRunReady {
+		e.cancelRerunPanels()
+		return
+	}
 	// if we have not completed, and already have a cancel function - cancel
-	if e.GetRunStatus() != dashboardinterfaces.DashboardRunReady || e.cancel == nil {
+	if e.cancel == nil {
 		return
 	}
 	e.cancel()
@@ -217,3 +243,54 @@ func (e *DashboardExecutionTree) Cancel() {
 func (e *DashboardExecutionTree) GetInputValue(name string) interface{} {
 	return e.inputValues[name]
 }
+
+// CancelPanel cancels the execution of a single panel - the rest of the execution continues
+func (e *DashboardExecutionTree) CancelPanel(panelName string) error {
+	leafRun, err := e.getLeafRun(panelName)
+	if err != nil {
+		return err
+	}
+	return leafRun.Cancel()
+}
+
+// RerunPanel re-executes a single panel, once the execution has completed
+func (e *DashboardExecutionTree) RerunPanel(ctx context.Context, panelName string) error {
+	if e.GetRunStatus() == dashboardinterfaces.DashboardRunReady {
+		return fmt.Errorf("panels cannot be re-run until the dashboard execution is complete")
+	}
+	leafRun, err := e.getLeafRun(panelName)
+	if err != nil {
+		return err
+	}
+	return leafRun.Rerun(ctx)
+}
+
+// GetLeafData returns the data of a completed panel
+func (e *DashboardExecutionTree) GetLeafData(panelName string) (*LeafData, error) {
+	leafRun, err := e.getLeafRun(panelName)
+	if err != nil {
+		return nil, err
+	}
+	data := leafRun.GetData()
+	if data == nil {
+		return nil, fmt.Errorf("panel '%s' has no data", panelName)
+	}
+	return data, nil
+}
+
+func (e *DashboardExecutionTree) getLeafRun(panelName string) (*LeafRun, error) {
+	leafRun, ok := e.runs[panelName].(*LeafRun)
+	if !ok {
+		return nil, fmt.Errorf("panel '%s' does not exist in the execution of '%s'", panelName, e.dashboardName)
+	}
+	return leafRun, nil
+}
+
+func (e *DashboardExecutionTree) cancelRerunPanels() {
+	for _, run := range e.runs {
+		if leafRun, ok := run.(*LeafRun); ok && leafRun.isRerun() {
+			// an error means the panel is not running
+			_ = leafRun.Cancel()
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
 	"github.com/turbot/steampipe/db/db_common"
@@ -15,6 +17,11 @@ type DashboardExecutor struct {
 	// map of executions, keyed by session id
 	executions    map[string]*DashboardExecutionTree
 	executionLock sync.Mutex
+
+	// limits the number of dashboard queries which run concurrently across all executions
+	// this is created on first use, once the config has been loaded
+	parallelismLock     *priorityLock
+	parallelismLockOnce sync.Once
 }
 
 func newDashboardExecutor() *DashboardExecutor {
@@ -97,6 +104,26 @@ func (e *DashboardExecutor) OnInputChanged(ctx context.Context, sessionId string
 	return nil
 }
 
+// ClearDependentInputs clears the values of any inputs which depend on the changed input,
+// using the execution for the given session to resolve the dependencies
+// it returns an InputValuesCleared event describing the cleared inputs, or nil if no inputs were cleared
+// NOTE: the event is not published - the caller is responsible for notifying the relevant client
+func (e *DashboardExecutor) ClearDependentInputs(sessionId string, inputs map[string]interface{}, changedInput string) *dashboardevents.InputValuesCleared {
+	executionTree, found := e.getExecution(sessionId)
+	if !found {
+		return nil
+	}
+	clearedInputs := e.clearDependentInputs(executionTree.Root, changedInput, inputs)
+	if len(clearedInputs) == 0 {
+		return nil
+	}
+	return &dashboardevents.InputValuesCleared{
+		ClearedInputs: clearedInputs,
+		Session:       sessionId,
+		ExecutionId:   executionTree.id,
+	}
+}
+
 func (e *DashboardExecutor) clearDependentInputs(root dashboardinterfaces.DashboardNodeRun, changedInput string, inputs map[string]interface{}) []string {
 	dependentInputs := root.GetInputsDependingOn(changedInput)
 	clearedInputs := dependentInputs
@@ -114,6 +141,24 @@ func (e *DashboardExecutor) clearDependentInputs(root dashboardinterfaces.Dashbo
 	return clearedInputs
 }
 
+// CancelPanel cancels the execution of a single panel of the execution for the session
+func (e *DashboardExecutor) CancelPanel(sessionId, panelName string) error {
+	executionTree, found := e.getExecution(sessionId)
+	if !found {
+		return fmt.Errorf("no dashboard running for session %s", sessionId)
+	}
+	return executionTree.CancelPanel(panelName)
+}
+
+// RerunPanel re-executes a single panel of the completed execution for the session
+func (e *DashboardExecutor) RerunPanel(ctx context.Context, sessionId, panelName string) error {
+	executionTree, found := e.getExecution(sessionId)
+	if !found {
+		return fmt.Errorf("no dashboard running for session %s", sessionId)
+	}
+	return executionTree.RerunPanel(ctx, panelName)
+}
+
 func (e *DashboardExecutor) CancelExecutionForSession(_ context.Context, sessionId string) {
 	// find the execution
 	executionTree, found := e.getExecution(sessionId)
@@ -128,6 +173,23 @@ func (e *DashboardExecutor) CancelExecutionForSession(_ context.Context, session
 	e.removeExecution(sessionId)
 }
 
+// GetLeafData returns the data of a panel of the execution for the session
+// NOTE: executions are only looked up by session id - callers must ensure the session id belongs to the caller
+func (e *DashboardExecutor) GetLeafData(sessionId, panelName string) (*LeafData, error) {
+	executionTree, found := e.getExecution(sessionId)
+	if !found {
+		return nil, fmt.Errorf("execution '%s' not found", sessionId)
+	}
+	return executionTree.GetLeafData(panelName)
+}
+
+func (e *DashboardExecutor) getParallelismLock() *priorityLock {
+	e.parallelismLockOnce.Do(func() {
+		e.parallelismLock = newPriorityLock(viper.GetInt(constants.ArgDashboardServerMaxParallel))
+	})
+	return e.parallelismLock
+}
+
 // find the execution for the given session id
 func (e *DashboardExecutor) getExecution(sessionId string) (*DashboardExecutionTree, bool) {
 	e.executionLock.Lock()
@@ -0,0 +1,26 @@
+package dashboardexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+func TestGetLeafDataBySessionOnly(t *testing.T) {
+	leafRun := &LeafRun{Name: "dashboard.d1.card.c1", DashboardNode: &modconfig.DashboardCard{}, Data: &LeafData{}, runStatus: dashboardinterfaces.DashboardRunComplete}
+	executionTree := &DashboardExecutionTree{
+		id:   "0xc000123456",
+		runs: map[string]dashboardinterfaces.DashboardNodeRun{leafRun.Name: leafRun},
+	}
+	e := newDashboardExecutor()
+	e.setExecution("session1", executionTree)
+
+	if _, err := e.GetLeafData("session1", leafRun.Name); err != nil {
+		t.Errorf("expected data for the session execution, got %v", err)
+	}
+	// the execution must not be found by its execution tree id, which is visible to other sessions
+	if _, err := e.GetLeafData(executionTree.id, leafRun.Name); err == nil {
+		t.Error("expected the execution not to be found by its execution tree id")
+	}
+}
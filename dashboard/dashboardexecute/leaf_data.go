@@ -2,10 +2,19 @@ package dashboardexecute
 
 import (
 	"database/sql"
+	"encoding/csv"
+	"io"
 
+	"github.com/turbot/steampipe/display"
 	"github.com/turbot/steampipe/query/queryresult"
 )
 
+// the formats in which leaf data may be downloaded
+const (
+	LeafDataFormatCSV  = "csv"
+	LeafDataFormatJSON = "json"
+)
+
 type LeafDataColumnType struct {
 	Name     string `json:"name"`
 	DataType string `json:"data_type_name"`
@@ -39,3 +48,37 @@ func NewLeafData(result *queryresult.SyncQueryResult) *LeafData {
 	}
 	return leafData
 }
+
+// WriteCSV writes the data as CSV, with a header row of column names
+// values are formatted according to their column type, as for 'steampipe query --output csv',
+// except that null values are written as empty fields
+func (d *LeafData) WriteCSV(w io.Writer) error {
+	csvWriter := csv.NewWriter(w)
+
+	columnNames := make([]string, len(d.Columns))
+	for i, c := range d.Columns {
+		columnNames[i] = c.Name
+	}
+	if err := csvWriter.Write(columnNames); err != nil {
+		return err
+	}
+
+	for _, row := range d.Rows {
+		rowAsString := make([]string, len(d.Columns))
+		for i, val := range row {
+			if val == nil {
+				continue
+			}
+			var err error
+			if rowAsString[i], err = display.ColumnValueAsStringForType(val, d.Columns[i].DataType); err != nil {
+				return err
+			}
+		}
+		if err := csvWriter.Write(rowAsString); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
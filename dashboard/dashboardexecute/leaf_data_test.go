@@ -0,0 +1,35 @@
+package dashboardexecute
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestLeafDataWriteCSV(t *testing.T) {
+	leafData := &LeafData{
+		Columns: []*LeafDataColumnType{
+			{Name: "name", DataType: "TEXT"},
+			{Name: "count", DataType: "INT8"},
+			{Name: "tags", DataType: "JSONB"},
+			{Name: "created", DataType: "TIMESTAMP"},
+		},
+		Rows: [][]interface{}{
+			{"a, b", int64(2), map[string]interface{}{"env": "prod"}, time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)},
+			{"c", nil, nil, nil},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := leafData.WriteCSV(&buf); err != nil {
+		t.Fatalf("Test: 'write csv' FAILED : unexpected error %v", err)
+	}
+
+	expected := `name,count,tags,created
+"a, b",2,"{""env"":""prod""}",2022-06-01 10:30:00
+c,,,
+`
+	if buf.String() != expected {
+		t.Errorf("Test: 'write csv' FAILED : expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
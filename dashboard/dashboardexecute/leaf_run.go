@@ -2,11 +2,17 @@ package dashboardexecute
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardinterfaces"
+	"github.com/turbot/steampipe/query/queryresult"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 )
 
@@ -30,6 +36,17 @@ type LeafRun struct {
 	runStatus           dashboardinterfaces.DashboardRunStatus
 	executionTree       *DashboardExecutionTree
 	runtimeDependencies map[string]*ResolvedRuntimeDependency
+	// cancels the execution of this run only
+	cancel     context.CancelFunc
+	cancelLock sync.Mutex
+	// set when the run is re-executed after the execution tree has completed
+	// - the parent is no longer waiting for the run to complete, so must not be notified
+	rerun bool
+	// guards the run state (Data, ErrorString, error, runStatus and rerun), which is reset when the run is
+	// re-executed, while payloads may be built from the run and its data read by other goroutines
+	stateLock sync.RWMutex
+	// the priority of the run when waiting for a parallelism lock - lower values run first
+	priority int
 }
 
 func NewLeafRun(resource modconfig.DashboardLeafNode, parent dashboardinterfaces.DashboardNodeParent, executionTree *DashboardExecutionTree) (*LeafRun, error) {
@@ -58,6 +75,7 @@ func NewLeafRun(resource modconfig.DashboardLeafNode, parent dashboardinterfaces
 		return nil, err
 	}
 	r.NodeType = parsedName.ItemType
+	r.priority = executionTree.nextRunPriority(r.NodeType == modconfig.BlockTypeInput)
 	// if we have a query provider which requires execution, set status to ready
 	if provider, ok := resource.(modconfig.QueryProvider); ok && provider.RequiresExecution(provider) {
 		// if the provider has sql or a query, set status to ready
@@ -94,12 +112,18 @@ func (r *LeafRun) Initialise(ctx context.Context) {}
 // Execute implements DashboardRunNode
 func (r *LeafRun) Execute(ctx context.Context) {
 	// if there is nothing to do, return
-	if r.runStatus == dashboardinterfaces.DashboardRunComplete {
+	if r.GetRunStatus() == dashboardinterfaces.DashboardRunComplete {
 		return
 	}
 
 	log.Printf("[TRACE] LeafRun '%s' Execute()", r.DashboardNode.Name())
 
+	// create a context which allows this run to be cancelled independently of its siblings
+	ctx, cancel := context.WithCancel(ctx)
+	r.setCancel(cancel)
+	defer r.setCancel(nil)
+	defer cancel()
+
 	// to get here, we must be a query provider
 
 	// if there are any unresolved runtime dependencies, wait for them
@@ -118,7 +142,7 @@ func (r *LeafRun) Execute(ctx context.Context) {
 
 	log.Printf("[TRACE] LeafRun '%s' SQL resolved, executing", r.DashboardNode.Name())
 
-	queryResult, err := r.executionTree.client.ExecuteSync(ctx, r.executeSQL)
+	queryResult, err := r.executeQuery(ctx)
 	if err != nil {
 		log.Printf("[TRACE] LeafRun '%s' query failed: %s", r.DashboardNode.Name(), err.Error())
 		// set the error status on the counter - this will raise counter error event
@@ -128,11 +152,123 @@ func (r *LeafRun) Execute(ctx context.Context) {
 	}
 	log.Printf("[TRACE] LeafRun '%s' complete", r.DashboardNode.Name())
 
+	r.stateLock.Lock()
 	r.Data = NewLeafData(queryResult)
+	r.stateLock.Unlock()
 	// set complete status on counter - this will raise counter complete event
 	r.SetComplete()
 }
 
+// executeQuery executes the query of the run, applying the query timeout if there is one
+// the query waits for the parallelism locks of the execution tree and the server before executing
+func (r *LeafRun) executeQuery(ctx context.Context) (*queryresult.SyncQueryResult, error) {
+	release, err := r.acquireParallelismLocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	timeout := r.getTimeout()
+	if timeout == 0 {
+		return r.executionTree.client.ExecuteSync(ctx, r.executeSQL)
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	queryResult, err := r.executionTree.client.ExecuteSync(queryCtx, r.executeSQL)
+	if err != nil && queryCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return nil, fmt.Errorf("query timed out after %s", timeout)
+	}
+	return queryResult, err
+}
+
+// acquireParallelismLocks acquires the execution tree lock, then the server lock
+// it returns a function which releases both locks
+func (r *LeafRun) acquireParallelismLocks(ctx context.Context) (func(), error) {
+	treeLock := r.executionTree.parallelismLock
+	if err := treeLock.Acquire(ctx, r.priority); err != nil {
+		return nil, err
+	}
+	serverLock := Executor.getParallelismLock()
+	if err := serverLock.Acquire(ctx, r.priority); err != nil {
+		treeLock.Release()
+		return nil, err
+	}
+	return func() {
+		serverLock.Release()
+		treeLock.Release()
+	}, nil
+}
+
+// getTimeout returns the query timeout of the run - the timeout of the dashboard node if set,
+// otherwise the global dashboard query timeout
+func (r *LeafRun) getTimeout() time.Duration {
+	if node, ok := r.DashboardNode.(modconfig.DashboardLeafNodeWithTimeout); ok {
+		if timeout := node.GetTimeout(); timeout > 0 {
+			return timeout
+		}
+	}
+	return time.Duration(viper.GetInt(constants.ArgDashboardQueryTimeout)) * time.Second
+}
+
+// Cancel cancels the execution of this run, without affecting the rest of the execution tree
+func (r *LeafRun) Cancel() error {
+	r.cancelLock.Lock()
+	defer r.cancelLock.Unlock()
+	if r.cancel == nil {
+		return fmt.Errorf("panel '%s' is not running", r.Name)
+	}
+	r.cancel()
+	return nil
+}
+
+// Rerun re-executes this run
+// NOTE: this must only be called once the execution tree has completed
+func (r *LeafRun) Rerun(ctx context.Context) error {
+	queryProvider, ok := r.DashboardNode.(modconfig.QueryProvider)
+	if !ok || !queryProvider.RequiresExecution(queryProvider) {
+		return fmt.Errorf("panel '%s' does not execute a query", r.Name)
+	}
+
+	// check the run is complete and reset its state atomically, so concurrent reruns cannot both start
+	r.stateLock.Lock()
+	if !r.runComplete() {
+		r.stateLock.Unlock()
+		return fmt.Errorf("panel '%s' is still running", r.Name)
+	}
+	log.Printf("[TRACE] LeafRun '%s' Rerun()", r.DashboardNode.Name())
+	r.rerun = true
+	r.error = nil
+	r.ErrorString = ""
+	r.Data = nil
+	r.runStatus = dashboardinterfaces.DashboardRunReady
+	r.stateLock.Unlock()
+
+	go r.Execute(ctx)
+	return nil
+}
+
+func (r *LeafRun) setCancel(cancel context.CancelFunc) {
+	r.cancelLock.Lock()
+	defer r.cancelLock.Unlock()
+	r.cancel = cancel
+}
+
+// isRerun returns whether the run has been re-executed after the execution tree completed
+func (r *LeafRun) isRerun() bool {
+	r.stateLock.RLock()
+	defer r.stateLock.RUnlock()
+	return r.rerun
+}
+
+// notifyParent tells the parent we are done
+func (r *LeafRun) notifyParent() {
+	if r.isRerun() {
+		return
+	}
+	r.parent.ChildCompleteChan() <- r
+}
+
 // GetName implements DashboardNodeRun
 func (r *LeafRun) GetName() string {
 	return r.Name
@@ -140,45 +276,80 @@ func (r *LeafRun) GetName() string {
 
 // GetRunStatus implements DashboardNodeRun
 func (r *LeafRun) GetRunStatus() dashboardinterfaces.DashboardRunStatus {
+	r.stateLock.RLock()
+	defer r.stateLock.RUnlock()
 	return r.runStatus
 }
 
+// GetData returns the data of the run, or nil if the run has not completed successfully
+func (r *LeafRun) GetData() *LeafData {
+	r.stateLock.RLock()
+	defer r.stateLock.RUnlock()
+	if r.runStatus != dashboardinterfaces.DashboardRunComplete {
+		return nil
+	}
+	return r.Data
+}
+
+// MarshalJSON implements json.Marshaler
+// the run state is locked while serialising, as the run may be re-executing
+func (r *LeafRun) MarshalJSON() ([]byte, error) {
+	// use a type without the MarshalJSON method to serialise the fields
+	type leafRun LeafRun
+	r.stateLock.RLock()
+	defer r.stateLock.RUnlock()
+	return json.Marshal((*leafRun)(r))
+}
+
 // SetError implements DashboardNodeRun
 func (r *LeafRun) SetError(err error) {
+	r.stateLock.Lock()
 	r.error = err
 	// error type does not serialise to JSON so copy into a string
 	r.ErrorString = err.Error()
-
 	r.runStatus = dashboardinterfaces.DashboardRunError
+	r.stateLock.Unlock()
+
 	// raise counter error event
 	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.LeafNodeError{
 		LeafNode:    r,
 		Session:     r.executionTree.sessionId,
 		ExecutionId: r.executionTree.id,
 	})
-	r.parent.ChildCompleteChan() <- r
+	r.notifyParent()
 }
 
 // GetError implements DashboardNodeRun
 func (r *LeafRun) GetError() error {
+	r.stateLock.RLock()
+	defer r.stateLock.RUnlock()
 	return r.error
 }
 
 // SetComplete implements DashboardNodeRun
 func (r *LeafRun) SetComplete() {
+	r.stateLock.Lock()
 	r.runStatus = dashboardinterfaces.DashboardRunComplete
+	r.stateLock.Unlock()
+
 	// raise counter complete event
 	r.executionTree.workspace.PublishDashboardEvent(&dashboardevents.LeafNodeComplete{
 		LeafNode:    r,
 		Session:     r.executionTree.sessionId,
 		ExecutionId: r.executionTree.id,
 	})
-	// tell parent we are done
-	r.parent.ChildCompleteChan() <- r
+	r.notifyParent()
 }
 
 // RunComplete implements DashboardNodeRun
 func (r *LeafRun) RunComplete() bool {
+	r.stateLock.RLock()
+	defer r.stateLock.RUnlock()
+	return r.runComplete()
+}
+
+// NOTE: must be called with the stateLock held
+func (r *LeafRun) runComplete() bool {
 	return r.runStatus == dashboardinterfaces.DashboardRunComplete || r.runStatus == dashboardinterfaces.DashboardRunError
 }
 
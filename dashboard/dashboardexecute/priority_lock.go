@@ -0,0 +1,129 @@
+package dashboardexecute
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// priorityLock is a counting semaphore which grants waiting acquirers in priority order - lowest value first
+// acquirers with the same priority are granted in the order they started waiting
+// a nil priorityLock imposes no limit
+type priorityLock struct {
+	size    int
+	current int
+	waiters priorityWaiters
+	// incremented for each waiter, to preserve the wait order of waiters with the same priority
+	sequence int64
+	mut      sync.Mutex
+}
+
+// newPriorityLock returns a lock allowing 'size' concurrent holders, or nil if size is not positive
+func newPriorityLock(size int) *priorityLock {
+	if size <= 0 {
+		return nil
+	}
+	return &priorityLock{size: size}
+}
+
+// Acquire blocks until the lock is acquired or the context is cancelled
+func (l *priorityLock) Acquire(ctx context.Context, priority int) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mut.Lock()
+	if l.current < l.size && len(l.waiters) == 0 {
+		l.current++
+		l.mut.Unlock()
+		return nil
+	}
+	waiter := &priorityWaiter{
+		priority: priority,
+		sequence: l.sequence,
+		ready:    make(chan struct{}),
+	}
+	l.sequence++
+	heap.Push(&l.waiters, waiter)
+	l.mut.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+		l.mut.Lock()
+		defer l.mut.Unlock()
+		select {
+		case <-waiter.ready:
+			// the lock was granted as the context was cancelled - pass it on
+			l.current--
+			l.grantLocked()
+		default:
+			heap.Remove(&l.waiters, waiter.index)
+		}
+		return ctx.Err()
+	}
+}
+
+// Release releases a lock obtained by Acquire
+func (l *priorityLock) Release() {
+	if l == nil {
+		return
+	}
+
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	l.current--
+	l.grantLocked()
+}
+
+// grant the lock to the highest priority waiters, while there is capacity
+// NOTE: must be called with the mutex held
+func (l *priorityLock) grantLocked() {
+	for l.current < l.size && len(l.waiters) > 0 {
+		waiter := heap.Pop(&l.waiters).(*priorityWaiter)
+		l.current++
+		close(waiter.ready)
+	}
+}
+
+type priorityWaiter struct {
+	priority int
+	sequence int64
+	ready    chan struct{}
+	// the index of the waiter in the heap - maintained by the heap.Interface methods
+	index int
+}
+
+// priorityWaiters implements heap.Interface
+type priorityWaiters []*priorityWaiter
+
+func (w priorityWaiters) Len() int { return len(w) }
+
+func (w priorityWaiters) Less(i, j int) bool {
+	if w[i].priority != w[j].priority {
+		return w[i].priority < w[j].priority
+	}
+	return w[i].sequence < w[j].sequence
+}
+
+func (w priorityWaiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *priorityWaiters) Push(x interface{}) {
+	waiter := x.(*priorityWaiter)
+	waiter.index = len(*w)
+	*w = append(*w, waiter)
+}
+
+func (w *priorityWaiters) Pop() interface{} {
+	old := *w
+	n := len(old)
+	waiter := old[n-1]
+	old[n-1] = nil
+	*w = old[:n-1]
+	return waiter
+}
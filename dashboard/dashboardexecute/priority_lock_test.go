@@ -0,0 +1,99 @@
+package dashboardexecute
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPriorityLockGrantsInPriorityOrder(t *testing.T) {
+	lock := newPriorityLock(1)
+	ctx := context.Background()
+	if err := lock.Acquire(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// queue waiters in an order which differs from their priority
+	priorities := []int{5, 1, 3, 1}
+	granted := make(chan int, len(priorities))
+	for idx, priority := range priorities {
+		go func(idx, priority int) {
+			if err := lock.Acquire(ctx, priority); err != nil {
+				t.Errorf("Test: 'priority order' FAILED : unexpected error %v", err)
+				return
+			}
+			granted <- idx
+			lock.Release()
+		}(idx, priority)
+		// wait for the goroutine to start waiting, so the wait order is deterministic
+		waitForWaiters(t, lock, idx+1)
+	}
+	lock.Release()
+
+	// equal priorities are granted in wait order
+	expected := []int{1, 3, 2, 0}
+	for _, expectedIdx := range expected {
+		select {
+		case idx := <-granted:
+			if idx != expectedIdx {
+				t.Fatalf("Test: 'priority order' FAILED : expected waiter %d to be granted, got %d", expectedIdx, idx)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Test: 'priority order' FAILED : timed out waiting for waiter %d", expectedIdx)
+		}
+	}
+}
+
+func TestPriorityLockCancelledWaiter(t *testing.T) {
+	lock := newPriorityLock(1)
+	if err := lock.Acquire(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error)
+	go func() {
+		errChan <- lock.Acquire(ctx, 0)
+	}()
+	waitForWaiters(t, lock, 1)
+	cancel()
+	if err := <-errChan; err == nil {
+		t.Fatalf("Test: 'cancelled waiter' FAILED : expected an error")
+	}
+
+	// the cancelled waiter must not hold the lock
+	lock.Release()
+	acquireCtx, acquireCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer acquireCancel()
+	if err := lock.Acquire(acquireCtx, 0); err != nil {
+		t.Fatalf("Test: 'cancelled waiter' FAILED : lock could not be acquired after cancellation: %v", err)
+	}
+}
+
+func TestNilPriorityLock(t *testing.T) {
+	lock := newPriorityLock(0)
+	if lock != nil {
+		t.Fatalf("Test: 'nil lock' FAILED : expected a nil lock for size 0")
+	}
+	// a nil lock never blocks
+	for i := 0; i < 10; i++ {
+		if err := lock.Acquire(context.Background(), 0); err != nil {
+			t.Fatalf("Test: 'nil lock' FAILED : unexpected error %v", err)
+		}
+	}
+	lock.Release()
+}
+
+func waitForWaiters(t *testing.T, lock *priorityLock, count int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		lock.mut.Lock()
+		waiting := len(lock.waiters)
+		lock.mut.Unlock()
+		if waiting == count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters", count)
+}
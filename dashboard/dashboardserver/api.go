@@ -37,7 +37,7 @@ func OpenBrowser(url string) error {
 	return exec.Command(cmd, args...).Start()
 }
 
-func startAPIAsync(ctx context.Context, webSocket *melody.Melody) chan struct{} {
+func startAPIAsync(ctx context.Context, webSocket *melody.Melody, security *serverSecurity, addRestRoutes func(api *gin.RouterGroup)) chan struct{} {
 	doneChan := make(chan struct{})
 
 	go func() {
@@ -45,6 +45,10 @@ func startAPIAsync(ctx context.Context, webSocket *melody.Melody) chan struct{}
 		router := gin.New()
 		// only add the Recovery middleware
 		router.Use(gin.Recovery())
+		// authenticate all requests - this covers the static UI, the websocket upgrade and the REST API
+		if security.auth != nil {
+			router.Use(security.auth)
+		}
 
 		assetsDirectory := filepaths.EnsureDashboardAssetsDir()
 
@@ -54,6 +58,8 @@ func startAPIAsync(ctx context.Context, webSocket *melody.Melody) chan struct{}
 			webSocket.HandleRequest(c.Writer, c.Request)
 		})
 
+		addRestRoutes(router.Group("/api/v1"))
+
 		router.NoRoute(func(c *gin.Context) {
 			c.File(path.Join(assetsDirectory, "index.html"))
 		})
@@ -71,13 +77,19 @@ func startAPIAsync(ctx context.Context, webSocket *melody.Melody) chan struct{}
 
 		go func() {
 			// service connections
-			if err := srv.ListenAndServe(); err != nil {
+			var err error
+			if security.tlsEnabled() {
+				err = srv.ListenAndServeTLS(security.tlsCertFile, security.tlsKeyFile)
+			} else {
+				err = srv.ListenAndServe()
+			}
+			if err != nil {
 				log.Printf("listen: %s\n", err)
 			}
 		}()
 
 		outputReady(ctx, fmt.Sprintf("Dashboard server started on %d and listening on %s", dashboardServerPort, viper.GetString(constants.ArgDashboardListen)))
-		OutputMessage(ctx, fmt.Sprintf("Visit http://localhost:%d", dashboardServerPort))
+		OutputMessage(ctx, fmt.Sprintf("Visit %s", DashboardUrl(dashboardServerPort, security.tlsEnabled())))
 		OutputMessage(ctx, "Press Ctrl+C to exit")
 		<-ctx.Done()
 		log.Println("Shutdown Server ...")
@@ -0,0 +1,71 @@
+package dashboardserver
+
+import (
+	"net/url"
+	"strings"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// the prefix of url query parameters which set dashboard input values, e.g.
+//
+//	/aws_insights.dashboard.aws_ec2_instance_dashboard?input.region=us-east-1
+const inputQueryParamPrefix = "input."
+
+// inputsFromQueryString parses the input values from the query string of a dashboard url
+func inputsFromQueryString(queryString, dashboardName string, resourceMaps *modconfig.ModResources) map[string]interface{} {
+	query, err := url.ParseQuery(strings.TrimPrefix(queryString, "?"))
+	if err != nil {
+		return nil
+	}
+	return InputsFromQuery(query, resourceMaps.Dashboards[dashboardName])
+}
+
+// InputsFromQuery extracts the input values from url query parameters
+// the values of multiselect and date_range inputs may be given as repeated parameters or comma separated values
+// if the dashboard is not known, all values are treated as strings
+func InputsFromQuery(query url.Values, dashboard *modconfig.Dashboard) map[string]interface{} {
+	inputs := make(map[string]interface{})
+	for name, values := range query {
+		if !strings.HasPrefix(name, inputQueryParamPrefix) || len(values) == 0 {
+			continue
+		}
+
+		inputType := ""
+		if dashboard != nil {
+			if input, ok := dashboard.GetInput(name); ok {
+				inputType = typehelpers.SafeString(input.Type)
+			}
+		}
+
+		switch inputType {
+		case modconfig.DashboardInputTypeMultiSelect, modconfig.DashboardInputTypeMultiCombo, modconfig.DashboardInputTypeDateRange:
+			var inputValues []interface{}
+			for _, value := range values {
+				for _, v := range strings.Split(value, ",") {
+					inputValues = append(inputValues, v)
+				}
+			}
+			inputs[name] = inputValues
+		default:
+			inputs[name] = values[0]
+		}
+	}
+	return inputs
+}
+
+// mergeInputs returns the union of the given input values - values in 'inputs' take precedence
+func mergeInputs(initialInputs, inputs map[string]interface{}) map[string]interface{} {
+	if len(initialInputs) == 0 {
+		return inputs
+	}
+	res := make(map[string]interface{}, len(initialInputs)+len(inputs))
+	for name, value := range initialInputs {
+		res[name] = value
+	}
+	for name, value := range inputs {
+		res[name] = value
+	}
+	return res
+}
@@ -0,0 +1,82 @@
+package dashboardserver
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+type inputsFromQueryTest struct {
+	query    string
+	expected map[string]interface{}
+}
+
+var testCasesInputsFromQuery = map[string]inputsFromQueryTest{
+	"single value": {
+		query:    "input.region=us-east-1",
+		expected: map[string]interface{}{"input.region": "us-east-1"},
+	},
+	"non input params ignored": {
+		query:    "wait=true&input.region=us-east-1&region=eu-west-1",
+		expected: map[string]interface{}{"input.region": "us-east-1"},
+	},
+	"multiselect comma separated": {
+		query:    "input.accounts=a,b",
+		expected: map[string]interface{}{"input.accounts": []interface{}{"a", "b"}},
+	},
+	"multiselect repeated": {
+		query:    "input.accounts=a&input.accounts=b,c",
+		expected: map[string]interface{}{"input.accounts": []interface{}{"a", "b", "c"}},
+	},
+	"date range": {
+		query:    "input.period=2022-06-01,2022-06-30",
+		expected: map[string]interface{}{"input.period": []interface{}{"2022-06-01", "2022-06-30"}},
+	},
+	"unknown input": {
+		query:    "input.other=a,b",
+		expected: map[string]interface{}{"input.other": "a,b"},
+	},
+}
+
+func TestInputsFromQuery(t *testing.T) {
+	mod := modconfig.NewMod("test", "", hcl.Range{})
+	dashboard := &modconfig.Dashboard{FullName: "test.dashboard.test", Mod: mod}
+	dashboard.SetInputs([]*modconfig.DashboardInput{
+		newTestDashboardInput("region", modconfig.DashboardInputTypeSelect),
+		newTestDashboardInput("accounts", modconfig.DashboardInputTypeMultiSelect),
+		newTestDashboardInput("period", modconfig.DashboardInputTypeDateRange),
+	})
+
+	for name, test := range testCasesInputsFromQuery {
+		query, err := url.ParseQuery(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputs := InputsFromQuery(query, dashboard)
+		if !reflect.DeepEqual(inputs, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, inputs)
+		}
+	}
+}
+
+func TestMergeInputs(t *testing.T) {
+	initialInputs := map[string]interface{}{"input.region": "us-east-1", "input.account": "a"}
+	inputs := map[string]interface{}{"input.region": "eu-west-1"}
+
+	expected := map[string]interface{}{"input.region": "eu-west-1", "input.account": "a"}
+	if merged := mergeInputs(initialInputs, inputs); !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Test: 'merge inputs' FAILED : expected %v, got %v", expected, merged)
+	}
+}
+
+func newTestDashboardInput(shortName, inputType string) *modconfig.DashboardInput {
+	return &modconfig.DashboardInput{
+		ShortName:       shortName,
+		FullName:        "test.input." + shortName,
+		UnqualifiedName: "input." + shortName,
+		Type:            &inputType,
+	}
+}
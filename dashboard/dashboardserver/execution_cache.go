@@ -0,0 +1,261 @@
+package dashboardserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/olahol/melody.v1"
+)
+
+// the prefix of the session ids used for shared executions
+const sharedSessionPrefix = "shared-"
+
+// sharedExecution is a dashboard execution which is shared by all client sessions viewing
+// the same dashboard with the same inputs
+type sharedExecution struct {
+	// the session id the execution runs under - events for this session are forwarded to all subscribers
+	executionId string
+	key         string
+	subscribers map[string]*melody.Session
+	// the payloads sent so far, replayed to sessions which join the execution
+	// once complete, only the execution started and execution complete payloads are retained
+	payloads    [][]byte
+	complete    bool
+	completedAt time.Time
+}
+
+// executionCache shares dashboard executions between client sessions
+// executions are keyed on the dashboard name, the input values and the workspace generation
+// completed executions are reused until their TTL expires
+type executionCache struct {
+	ttl time.Duration
+	// incremented whenever the workspace changes, so executions of the previous workspace are not reused
+	generation int
+	// shared executions keyed by cache key
+	executions map[string]*sharedExecution
+	// shared executions keyed by execution id
+	executionsById map[string]*sharedExecution
+	// the execution each client session is subscribed to, keyed by session id
+	sessionExecutions map[string]*sharedExecution
+	mut               sync.Mutex
+}
+
+func newExecutionCache(ttl time.Duration) *executionCache {
+	return &executionCache{
+		ttl:               ttl,
+		executions:        make(map[string]*sharedExecution),
+		executionsById:    make(map[string]*sharedExecution),
+		sessionExecutions: make(map[string]*sharedExecution),
+	}
+}
+
+// join subscribes the client session to the shared execution of the dashboard with the given inputs,
+// replaying any payloads already sent for the execution
+// if there is no reusable execution, a new one is created and its execution id returned, so that the caller can start it
+// the session leaves any execution it was previously subscribed to
+// the ids of any executions which are no longer needed are returned so that they can be cancelled
+func (c *executionCache) join(sessionId string, session *melody.Session, dashboardName string, inputs map[string]interface{}) (newExecutionId string, unusedExecutionIds []string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	unusedExecutionIds = c.leaveLocked(sessionId)
+	unusedExecutionIds = append(unusedExecutionIds, c.evictExpiredLocked()...)
+
+	key := c.cacheKey(dashboardName, inputs)
+	execution, ok := c.executions[key]
+	if ok && execution.complete && time.Since(execution.completedAt) > c.ttl {
+		// the results are stale - any existing subscribers keep them, but they are not reused
+		delete(c.executions, key)
+		ok = false
+	}
+	if ok {
+		log.Printf("[TRACE] session %s joining shared execution %s of %s", sessionId, execution.executionId, dashboardName)
+		for _, payload := range execution.payloads {
+			_ = session.Write(payload)
+		}
+	} else {
+		execution = &sharedExecution{
+			executionId: sharedSessionPrefix + uuid.New().String(),
+			key:         key,
+			subscribers: make(map[string]*melody.Session),
+		}
+		c.executions[key] = execution
+		c.executionsById[execution.executionId] = execution
+		newExecutionId = execution.executionId
+		log.Printf("[TRACE] session %s starting shared execution %s of %s", sessionId, execution.executionId, dashboardName)
+	}
+	execution.subscribers[sessionId] = session
+	c.sessionExecutions[sessionId] = execution
+	return newExecutionId, unusedExecutionIds
+}
+
+// leave unsubscribes the client session from its shared execution
+// the ids of any executions which are no longer needed are returned so that they can be cancelled
+func (c *executionCache) leave(sessionId string) []string {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.leaveLocked(sessionId)
+}
+
+// getExecutionId returns the id of the shared execution the client session is subscribed to
+func (c *executionCache) getExecutionId(sessionId string) (string, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	execution, ok := c.sessionExecutions[sessionId]
+	if !ok {
+		return "", false
+	}
+	return execution.executionId, true
+}
+
+// detach stops the shared execution the client session is subscribed to from being reused, as the session
+// is altering its results by cancelling or re-running a panel, and returns the execution id
+// if exclusive is set, the execution is only detached if the session is its only subscriber
+func (c *executionCache) detach(sessionId string, exclusive bool) (string, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	execution, ok := c.sessionExecutions[sessionId]
+	if !ok || (exclusive && len(execution.subscribers) > 1) {
+		return "", false
+	}
+	if c.executions[execution.key] == execution {
+		delete(c.executions, execution.key)
+	}
+	return execution.executionId, true
+}
+
+// publish forwards a payload for a shared execution to all of its subscribers
+// it returns false if executionId is not a shared execution
+func (c *executionCache) publish(executionId string, payload []byte) bool {
+	if !isSharedSession(executionId) {
+		return false
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	execution, ok := c.executionsById[executionId]
+	if !ok {
+		// the execution has been evicted - nobody is interested
+		return true
+	}
+	execution.payloads = append(execution.payloads, payload)
+	for _, session := range execution.subscribers {
+		_ = session.Write(payload)
+	}
+	return true
+}
+
+// setComplete marks the shared execution as complete, so it may be reused until the TTL expires
+func (c *executionCache) setComplete(executionId string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	execution, ok := c.executionsById[executionId]
+	if !ok {
+		return
+	}
+	execution.complete = true
+	execution.completedAt = time.Now()
+	// the execution complete payload contains the full results - the leaf node payloads are no longer needed
+	if len(execution.payloads) > 2 {
+		execution.payloads = [][]byte{execution.payloads[0], execution.payloads[len(execution.payloads)-1]}
+	}
+}
+
+// setError removes a failed execution from the cache, so that it is not reused
+// the subscribers remain subscribed, so they receive no further events until they select a dashboard
+func (c *executionCache) setError(executionId string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if execution, ok := c.executionsById[executionId]; ok {
+		delete(c.executions, execution.key)
+	}
+}
+
+// invalidate stops any existing executions from being reused, as the workspace has changed
+// the ids of complete executions with no subscribers are returned so that they can be cancelled
+func (c *executionCache) invalidate() []string {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.generation++
+	c.executions = make(map[string]*sharedExecution)
+
+	var unused []string
+	for _, execution := range c.executionsById {
+		if len(execution.subscribers) == 0 && execution.complete {
+			c.removeLocked(execution)
+			unused = append(unused, execution.executionId)
+		}
+	}
+	return unused
+}
+
+// NOTE: must be called with the mutex held
+func (c *executionCache) leaveLocked(sessionId string) []string {
+	execution, ok := c.sessionExecutions[sessionId]
+	if !ok {
+		return nil
+	}
+	delete(c.sessionExecutions, sessionId)
+	delete(execution.subscribers, sessionId)
+
+	// a running execution with no subscribers is of no use to anyone
+	// a complete execution is retained until it expires, unless it can no longer be reused
+	if len(execution.subscribers) == 0 && (!execution.complete || c.executions[execution.key] != execution) {
+		c.removeLocked(execution)
+		return []string{execution.executionId}
+	}
+	return nil
+}
+
+// NOTE: must be called with the mutex held
+func (c *executionCache) evictExpiredLocked() []string {
+	var evicted []string
+	for _, execution := range c.executionsById {
+		if len(execution.subscribers) == 0 && execution.complete && time.Since(execution.completedAt) > c.ttl {
+			c.removeLocked(execution)
+			evicted = append(evicted, execution.executionId)
+		}
+	}
+	return evicted
+}
+
+// NOTE: must be called with the mutex held
+func (c *executionCache) removeLocked(execution *sharedExecution) {
+	if c.executions[execution.key] == execution {
+		delete(c.executions, execution.key)
+	}
+	delete(c.executionsById, execution.executionId)
+}
+
+// cacheKey builds the cache key for an execution of the dashboard with the given inputs
+// inputs with no value are ignored, as these are equivalent to unset inputs
+func (c *executionCache) cacheKey(dashboardName string, inputs map[string]interface{}) string {
+	setInputs := make(map[string]interface{}, len(inputs))
+	for name, value := range inputs {
+		if value != nil {
+			setInputs[name] = value
+		}
+	}
+	// json.Marshal sorts map keys, so equal input maps produce the same json
+	inputJson, _ := json.Marshal(setInputs)
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s", dashboardName, c.generation, inputJson)))
+	return hex.EncodeToString(hash[:])
+}
+
+func isSharedSession(sessionId string) bool {
+	return strings.HasPrefix(sessionId, sharedSessionPrefix)
+}
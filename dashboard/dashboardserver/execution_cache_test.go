@@ -0,0 +1,102 @@
+package dashboardserver
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/olahol/melody.v1"
+)
+
+func TestExecutionCacheSharesExecutions(t *testing.T) {
+	cache := newExecutionCache(time.Minute)
+
+	firstId, _ := cache.join("s1", &melody.Session{}, "mod.dashboard.d1", map[string]interface{}{"region": "us-east-1"})
+	if firstId == "" {
+		t.Fatalf("Test: 'first join' FAILED : expected a new execution")
+	}
+	// same dashboard and inputs - a nil input is equivalent to an unset input
+	if id, _ := cache.join("s2", &melody.Session{}, "mod.dashboard.d1", map[string]interface{}{"region": "us-east-1", "vpc": nil}); id != "" {
+		t.Errorf("Test: 'same inputs' FAILED : expected the execution to be shared, got new execution %s", id)
+	}
+	// different inputs
+	if id, _ := cache.join("s3", &melody.Session{}, "mod.dashboard.d1", map[string]interface{}{"region": "eu-west-1"}); id == "" || id == firstId {
+		t.Errorf("Test: 'different inputs' FAILED : expected a new execution")
+	}
+
+	// the running execution is only unused once both sessions leave
+	if unused := cache.leave("s1"); len(unused) != 0 {
+		t.Errorf("Test: 'leave shared' FAILED : expected no unused executions, got %v", unused)
+	}
+	if unused := cache.leave("s2"); len(unused) != 1 || unused[0] != firstId {
+		t.Errorf("Test: 'leave last' FAILED : expected %s to be unused, got %v", firstId, unused)
+	}
+}
+
+func TestExecutionCacheReusesCompleteExecutions(t *testing.T) {
+	cache := newExecutionCache(time.Minute)
+
+	executionId, _ := cache.join("s1", &melody.Session{}, "mod.dashboard.d1", nil)
+	cache.setComplete(executionId)
+	if unused := cache.leave("s1"); len(unused) != 0 {
+		t.Errorf("Test: 'leave complete' FAILED : expected the complete execution to be retained, got %v", unused)
+	}
+
+	// joining with no payloads recorded does not write to the session
+	if id, _ := cache.join("s2", &melody.Session{}, "mod.dashboard.d1", nil); id != "" {
+		t.Errorf("Test: 'reuse complete' FAILED : expected the complete execution to be reused, got new execution %s", id)
+	}
+
+	// once the workspace changes, the execution is not reused
+	cache.invalidate()
+	if id, _ := cache.join("s2", &melody.Session{}, "mod.dashboard.d1", nil); id == "" {
+		t.Errorf("Test: 'invalidated' FAILED : expected a new execution")
+	}
+}
+
+func TestExecutionCacheExpiry(t *testing.T) {
+	cache := newExecutionCache(time.Millisecond)
+
+	executionId, _ := cache.join("s1", &melody.Session{}, "mod.dashboard.d1", nil)
+	cache.setComplete(executionId)
+	cache.leave("s1")
+	time.Sleep(5 * time.Millisecond)
+
+	id, unused := cache.join("s2", &melody.Session{}, "mod.dashboard.d1", nil)
+	if id == "" {
+		t.Errorf("Test: 'expired' FAILED : expected a new execution")
+	}
+	if len(unused) != 1 || unused[0] != executionId {
+		t.Errorf("Test: 'expired' FAILED : expected %s to be evicted, got %v", executionId, unused)
+	}
+}
+
+func TestExecutionCacheDetach(t *testing.T) {
+	cache := newExecutionCache(time.Minute)
+
+	executionId, _ := cache.join("s1", &melody.Session{}, "mod.dashboard.d1", nil)
+	cache.join("s2", &melody.Session{}, "mod.dashboard.d1", nil)
+
+	// a panel may not be cancelled while another session shares the execution
+	if _, ok := cache.detach("s1", true); ok {
+		t.Errorf("Test: 'exclusive shared' FAILED : expected the shared execution not to be detached")
+	}
+	if id, _ := cache.join("s3", &melody.Session{}, "mod.dashboard.d1", nil); id != "" {
+		t.Errorf("Test: 'exclusive shared' FAILED : expected the execution to still be shared, got new execution %s", id)
+	}
+
+	// re-running a panel stops the execution being reused
+	if id, ok := cache.detach("s1", false); !ok || id != executionId {
+		t.Errorf("Test: 'detach' FAILED : expected %s to be detached, got %s", executionId, id)
+	}
+	if id, _ := cache.join("s4", &melody.Session{}, "mod.dashboard.d1", nil); id == "" || id == executionId {
+		t.Errorf("Test: 'detached' FAILED : expected a new execution")
+	}
+
+	// the only subscriber may cancel a panel
+	if id, ok := cache.detach("s4", true); !ok || id == executionId {
+		t.Errorf("Test: 'exclusive only subscriber' FAILED : expected the execution of s4 to be detached, got %s", id)
+	}
+	if _, ok := cache.detach("unknown", false); ok {
+		t.Errorf("Test: 'unknown session' FAILED : expected no execution")
+	}
+}
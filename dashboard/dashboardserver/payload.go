@@ -1,12 +1,14 @@
 package dashboardserver
 
 import (
+	"bytes"
 	"encoding/json"
 
 	"github.com/spf13/viper"
 	typeHelpers "github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
 	"github.com/turbot/steampipe/steampipeconfig"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 )
@@ -71,6 +73,17 @@ func addBenchmarkChildren(benchmark *modconfig.Benchmark, recordTrunk bool, trun
 }
 
 func buildAvailableDashboardsPayload(workspaceResources *modconfig.ModResources) ([]byte, error) {
+	dashboards, benchmarks := buildAvailableDashboards(workspaceResources)
+	payload := AvailableDashboardsPayload{
+		Action:     "available_dashboards",
+		Dashboards: dashboards,
+		Benchmarks: benchmarks,
+	}
+	return json.Marshal(payload)
+}
+
+// buildAvailableDashboards returns maps of the dashboards and benchmarks available in the workspace, keyed by full name
+func buildAvailableDashboards(workspaceResources *modconfig.ModResources) (map[string]ModAvailableDashboard, map[string]ModAvailableBenchmark) {
 	// build a map of the dashboards provided by each mod
 	dashboards := make(map[string]ModAvailableDashboard)
 
@@ -129,13 +142,7 @@ func buildAvailableDashboardsPayload(workspaceResources *modconfig.ModResources)
 			benchmarks[benchmarkName] = foundBenchmark
 		}
 	}
-
-	payload := AvailableDashboardsPayload{
-		Action:     "available_dashboards",
-		Dashboards: dashboards,
-		Benchmarks: benchmarks,
-	}
-	return json.Marshal(payload)
+	return dashboards, benchmarks
 }
 
 func buildWorkspaceErrorPayload(e *dashboardevents.WorkspaceError) ([]byte, error) {
@@ -216,3 +223,37 @@ func buildInputValuesClearedPayload(event *dashboardevents.InputValuesCleared) (
 	}
 	return json.Marshal(payload)
 }
+
+func buildInputValidationErrorPayload(dashboardName string, inputErrors map[string]string) ([]byte, error) {
+	payload := InputValidationErrorPayload{
+		Action:    "input_validation_error",
+		Dashboard: dashboardName,
+		Errors:    inputErrors,
+	}
+	return json.Marshal(payload)
+}
+
+func buildPanelDataPayload(executionId, panelName, format string) ([]byte, error) {
+	payload := PanelDataPayload{
+		Action: "panel_data",
+		Panel:  panelName,
+		Format: format,
+	}
+
+	leafData, err := dashboardexecute.Executor.GetLeafData(executionId, panelName)
+	switch {
+	case err != nil:
+		payload.Error = err.Error()
+	case format == dashboardexecute.LeafDataFormatCSV:
+		var csvData bytes.Buffer
+		if err := leafData.WriteCSV(&csvData); err != nil {
+			payload.Error = err.Error()
+		} else {
+			payload.Data = csvData.String()
+		}
+	default:
+		payload.Format = dashboardexecute.LeafDataFormatJSON
+		payload.Data = leafData
+	}
+	return json.Marshal(payload)
+}
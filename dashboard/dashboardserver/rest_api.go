@@ -0,0 +1,303 @@
+package dashboardserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/turbot/steampipe/dashboard/dashboardevents"
+	"github.com/turbot/steampipe/dashboard/dashboardexecute"
+)
+
+// the number of finished REST API executions whose results are retained
+const maxRetainedApiExecutions = 100
+
+// the prefix of the session ids used for REST API executions
+// this distinguishes them from websocket sessions, which are identified by the session pointer
+const apiSessionPrefix = "api-"
+
+// addRestRoutes adds the REST API routes to the given router group:
+//
+//	GET    /dashboards                            list the available dashboards
+//	GET    /benchmarks                            list the available benchmarks
+//	POST   /dashboards/:name/executions           execute a dashboard
+//	POST   /benchmarks/:name/executions           execute a benchmark
+//	GET    /executions/:id                        get the status (and results) of an execution
+//	DELETE /executions/:id                        cancel an execution
+//	GET    /executions/:id/panels/:panel/data     download the data of a panel of an execution
+//
+// the execute and get execution routes accept a 'wait=true' query parameter,
+// which waits for the execution to complete before responding
+// the execute routes also accept input values as query parameters, e.g. 'input.region=us-east-1'
+// - input values in the request body take precedence
+func (s *Server) addRestRoutes(api *gin.RouterGroup) {
+	api.GET("/dashboards", s.handleListDashboards)
+	api.GET("/benchmarks", s.handleListBenchmarks)
+	api.POST("/dashboards/:name/executions", s.handleExecuteDashboard)
+	api.POST("/benchmarks/:name/executions", s.handleExecuteDashboard)
+	api.GET("/executions/:id", s.handleGetExecution)
+	api.DELETE("/executions/:id", s.handleCancelExecution)
+	api.GET("/executions/:id/panels/:panel/data", s.handleGetPanelData)
+}
+
+func (s *Server) handleListDashboards(c *gin.Context) {
+	dashboards, _ := buildAvailableDashboards(s.workspace.GetResourceMaps())
+	c.JSON(http.StatusOK, ApiDashboardsResponse{Dashboards: dashboards})
+}
+
+func (s *Server) handleListBenchmarks(c *gin.Context) {
+	_, benchmarks := buildAvailableDashboards(s.workspace.GetResourceMaps())
+	c.JSON(http.StatusOK, ApiBenchmarksResponse{Benchmarks: benchmarks})
+}
+
+func (s *Server) handleExecuteDashboard(c *gin.Context) {
+	name := c.Param("name")
+	if !s.isExecutable(name) {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: fmt.Sprintf("'%s' does not exist in workspace", name)})
+		return
+	}
+
+	var request ApiExecutionRequest
+	// an empty body means no inputs
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, ApiErrorResponse{Error: fmt.Sprintf("invalid request body: %s", err.Error())})
+			return
+		}
+	}
+
+	// inputs may also be passed as query parameters, e.g. ?input.region=us-east-1
+	queryInputs := InputsFromQuery(c.Request.URL.Query(), s.workspace.GetResourceMaps().Dashboards[name])
+	inputs := mergeInputs(queryInputs, request.Inputs)
+	if inputErrors := s.validateInputValues(name, inputs); len(inputErrors) > 0 {
+		c.JSON(http.StatusBadRequest, ApiErrorResponse{Error: "invalid input values", InputErrors: inputErrors})
+		return
+	}
+
+	execution := s.startApiExecution(name, inputs)
+	if !waitRequested(c) {
+		c.JSON(http.StatusAccepted, s.getApiExecution(execution.ExecutionId))
+		return
+	}
+	// this request started the execution, so cancel it if the client goes away before it completes
+	s.respondWhenComplete(c, execution, true)
+}
+
+func (s *Server) handleGetExecution(c *gin.Context) {
+	execution := s.getApiExecution(c.Param("id"))
+	if execution == nil {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: fmt.Sprintf("execution '%s' not found", c.Param("id"))})
+		return
+	}
+	if !waitRequested(c) {
+		c.JSON(http.StatusOK, execution)
+		return
+	}
+	// the execution was started by another request, so must not be cancelled if this client goes away
+	s.respondWhenComplete(c, execution, false)
+}
+
+func (s *Server) handleCancelExecution(c *gin.Context) {
+	executionId := c.Param("id")
+	if s.getApiExecution(executionId) == nil {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: fmt.Sprintf("execution '%s' not found", executionId)})
+		return
+	}
+	s.cancelApiExecution(s.context, executionId)
+	c.Status(http.StatusNoContent)
+}
+
+// handleGetPanelData responds with the data of the panel as an attachment, in the format given by
+// the 'format' query parameter - either 'json' (the default) or 'csv'
+// only REST API executions may be read - the executions of websocket sessions belong to those sessions
+func (s *Server) handleGetPanelData(c *gin.Context) {
+	executionId := c.Param("id")
+	if !isApiSession(executionId) {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: fmt.Sprintf("execution '%s' not found", executionId)})
+		return
+	}
+	panelName := c.Param("panel")
+	format := c.DefaultQuery("format", dashboardexecute.LeafDataFormatJSON)
+	if format != dashboardexecute.LeafDataFormatJSON && format != dashboardexecute.LeafDataFormatCSV {
+		c.JSON(http.StatusBadRequest, ApiErrorResponse{Error: fmt.Sprintf("invalid format '%s' - must be one of '%s' or '%s'", format, dashboardexecute.LeafDataFormatJSON, dashboardexecute.LeafDataFormatCSV)})
+		return
+	}
+
+	leafData, err := dashboardexecute.Executor.GetLeafData(executionId, panelName)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ApiErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.%s", panelName, format)))
+	if format == dashboardexecute.LeafDataFormatJSON {
+		c.JSON(http.StatusOK, leafData)
+		return
+	}
+	c.Header("Content-Type", "text/csv")
+	c.Status(http.StatusOK)
+	if err := leafData.WriteCSV(c.Writer); err != nil {
+		log.Printf("[WARN] failed to write data of panel %s: %s", panelName, err.Error())
+	}
+}
+
+// respondWhenComplete waits for the execution to finish and responds with its final state
+// if the client goes away first and cancelOnDisconnect is set, the execution is cancelled,
+// otherwise the execution continues and may be retrieved later
+func (s *Server) respondWhenComplete(c *gin.Context, execution *ApiExecution, cancelOnDisconnect bool) {
+	select {
+	case <-execution.done:
+		res := s.getApiExecution(execution.ExecutionId)
+		if res == nil {
+			// the execution was cancelled while we were waiting
+			c.JSON(http.StatusGone, ApiErrorResponse{Error: fmt.Sprintf("execution '%s' was cancelled", execution.ExecutionId)})
+			return
+		}
+		c.JSON(http.StatusOK, res)
+	case <-c.Request.Context().Done():
+		if !cancelOnDisconnect {
+			log.Printf("[TRACE] REST API client went away waiting for execution %s", execution.ExecutionId)
+			return
+		}
+		log.Printf("[TRACE] REST API client went away waiting for execution %s - cancelling", execution.ExecutionId)
+		s.cancelApiExecution(s.context, execution.ExecutionId)
+	}
+}
+
+// isExecutable returns whether name is the full name of a dashboard or benchmark in the workspace
+func (s *Server) isExecutable(name string) bool {
+	resourceMaps := s.workspace.GetResourceMaps()
+	if _, ok := resourceMaps.Dashboards[name]; ok {
+		return true
+	}
+	_, ok := resourceMaps.Benchmarks[name]
+	return ok
+}
+
+func waitRequested(c *gin.Context) bool {
+	wait, _ := strconv.ParseBool(c.Query("wait"))
+	return wait
+}
+
+func isApiSession(sessionId string) bool {
+	return strings.HasPrefix(sessionId, apiSessionPrefix)
+}
+
+// startApiExecution starts an execution of the dashboard or benchmark which is tracked in the REST API executions
+// the execution uses the server context, so it is not cancelled when the request completes
+func (s *Server) startApiExecution(name string, inputs map[string]interface{}) *ApiExecution {
+	execution := s.addApiExecution(name)
+	log.Printf("[TRACE] REST API execution %s started for %s", execution.ExecutionId, name)
+
+	if err := dashboardexecute.Executor.ExecuteDashboard(s.context, execution.ExecutionId, name, inputs, s.workspace, s.dbClient); err != nil {
+		s.setApiExecutionError(execution.ExecutionId, err)
+	}
+	return execution
+}
+
+// functions providing locked access to the REST API executions
+
+func (s *Server) addApiExecution(dashboardName string) *ApiExecution {
+	s.apiExecutionLock.Lock()
+	defer s.apiExecutionLock.Unlock()
+
+	execution := &ApiExecution{
+		ExecutionId: apiSessionPrefix + uuid.New().String(),
+		Dashboard:   dashboardName,
+		Status:      ApiExecutionRunning,
+		StartTime:   time.Now(),
+		done:        make(chan struct{}),
+	}
+	s.apiExecutions[execution.ExecutionId] = execution
+	s.evictApiExecutions()
+	return execution
+}
+
+// getApiExecution returns a copy of the execution with the given id, or nil if it does not exist
+func (s *Server) getApiExecution(executionId string) *ApiExecution {
+	s.apiExecutionLock.Lock()
+	defer s.apiExecutionLock.Unlock()
+
+	execution, ok := s.apiExecutions[executionId]
+	if !ok {
+		return nil
+	}
+	res := *execution
+	return &res
+}
+
+func (s *Server) setApiExecutionComplete(event *dashboardevents.ExecutionComplete, payload []byte) {
+	s.apiExecutionLock.Lock()
+	defer s.apiExecutionLock.Unlock()
+
+	execution, ok := s.apiExecutions[event.Session]
+	if !ok || execution.Status != ApiExecutionRunning {
+		return
+	}
+	execution.Status = ApiExecutionComplete
+	if err := event.Root.GetError(); err != nil {
+		execution.Status = ApiExecutionError
+		execution.Error = err.Error()
+	}
+	execution.Result = json.RawMessage(payload)
+	close(execution.done)
+}
+
+func (s *Server) setApiExecutionError(executionId string, err error) {
+	s.apiExecutionLock.Lock()
+	defer s.apiExecutionLock.Unlock()
+
+	execution, ok := s.apiExecutions[executionId]
+	if !ok || execution.Status != ApiExecutionRunning {
+		return
+	}
+	execution.Status = ApiExecutionError
+	execution.Error = err.Error()
+	close(execution.done)
+}
+
+func (s *Server) cancelApiExecution(ctx context.Context, executionId string) {
+	dashboardexecute.Executor.CancelExecutionForSession(ctx, executionId)
+
+	s.apiExecutionLock.Lock()
+	defer s.apiExecutionLock.Unlock()
+	// release any clients waiting for the execution
+	if execution, ok := s.apiExecutions[executionId]; ok && execution.Status == ApiExecutionRunning {
+		execution.Status = ApiExecutionError
+		execution.Error = "execution cancelled"
+		close(execution.done)
+	}
+	delete(s.apiExecutions, executionId)
+}
+
+// evictApiExecutions removes the oldest finished executions, and their execution trees,
+// once more than maxRetainedApiExecutions are retained
+// running executions are never evicted
+// NOTE: must be called with the apiExecutionLock held
+func (s *Server) evictApiExecutions() {
+	var finished []*ApiExecution
+	for _, execution := range s.apiExecutions {
+		if execution.Status != ApiExecutionRunning {
+			finished = append(finished, execution)
+		}
+	}
+	if len(finished) <= maxRetainedApiExecutions {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].StartTime.Before(finished[j].StartTime)
+	})
+	for _, execution := range finished[:len(finished)-maxRetainedApiExecutions] {
+		delete(s.apiExecutions, execution.ExecutionId)
+		// release the execution tree
+		dashboardexecute.Executor.CancelExecutionForSession(s.context, execution.ExecutionId)
+	}
+}
@@ -0,0 +1,28 @@
+package dashboardserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetExecutionWaitDisconnectDoesNotCancel(t *testing.T) {
+	s := &Server{context: context.Background(), apiExecutions: make(map[string]*ApiExecution)}
+	execution := s.addApiExecution("dashboard.d1")
+
+	// a client which goes away while waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/executions/"+execution.ExecutionId+"?wait=true", nil).WithContext(ctx)
+
+	s.respondWhenComplete(c, execution, false)
+
+	res := s.getApiExecution(execution.ExecutionId)
+	if res == nil || res.Status != ApiExecutionRunning {
+		t.Errorf("expected execution to still be running, got %+v", res)
+	}
+}
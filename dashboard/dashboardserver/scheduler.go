@@ -0,0 +1,210 @@
+package dashboardserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/control/controldisplay"
+	"github.com/turbot/steampipe/control/controlexecute"
+	"github.com/turbot/steampipe/filepaths"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// the format of the run time in the names of scheduled output files - this sorts chronologically
+const scheduleTimeFormat = "20060102T150405Z"
+
+// runSchedules runs each of the schedules on its own goroutine, until the context is cancelled
+func (s *Server) runSchedules(ctx context.Context, schedules []*modconfig.Schedule) {
+	// benchmark results are exported using the check export templates
+	if err := controldisplay.EnsureTemplates(); err != nil {
+		outputError(ctx, fmt.Errorf("failed to install check export templates: %s", err.Error()))
+	}
+	for _, schedule := range schedules {
+		OutputMessage(ctx, fmt.Sprintf("Schedule '%s' running %s on '%s'", schedule.Name, strings.Join(schedule.Targets, ", "), schedule.Cron))
+		go s.runSchedule(ctx, schedule)
+	}
+}
+
+func (s *Server) runSchedule(ctx context.Context, schedule *modconfig.Schedule) {
+	for {
+		nextRun := schedule.NextRun(time.Now())
+		if nextRun.IsZero() {
+			outputError(ctx, fmt.Errorf("schedule '%s' will never run - '%s' does not match any time", schedule.Name, schedule.Cron))
+			return
+		}
+		log.Printf("[TRACE] schedule %s next run at %s", schedule.Name, nextRun)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(nextRun)):
+		}
+
+		// the targets are run sequentially - a run which overlaps the next scheduled time means that run is skipped
+		s.runScheduledTargets(ctx, schedule, nextRun)
+	}
+}
+
+func (s *Server) runScheduledTargets(ctx context.Context, schedule *modconfig.Schedule, runTime time.Time) {
+	outputDir, err := scheduleOutputDir(schedule)
+	if err != nil {
+		outputError(ctx, fmt.Errorf("schedule '%s' cannot write to its output directory: %s", schedule.Name, err.Error()))
+		return
+	}
+
+	for _, target := range schedule.Targets {
+		OutputWait(ctx, fmt.Sprintf("Schedule '%s' running '%s'", schedule.Name, target))
+		targetCtx, cancel := context.WithTimeout(ctx, schedule.GetTimeout())
+		err := s.runScheduledTarget(targetCtx, schedule, target, outputDir, runTime)
+		cancel()
+		if err != nil {
+			outputError(ctx, fmt.Errorf("schedule '%s' failed to run '%s': %s", schedule.Name, target, err.Error()))
+			continue
+		}
+		if err := applyScheduleRetention(outputDir, target, schedule.GetRetention()); err != nil {
+			outputError(ctx, fmt.Errorf("schedule '%s' failed to remove old results of '%s': %s", schedule.Name, target, err.Error()))
+		}
+		outputReady(ctx, fmt.Sprintf("Schedule '%s' completed '%s'", schedule.Name, target))
+	}
+}
+
+func (s *Server) runScheduledTarget(ctx context.Context, schedule *modconfig.Schedule, target, outputDir string, runTime time.Time) error {
+	if !s.isExecutable(target) {
+		return fmt.Errorf("'%s' does not exist in workspace", target)
+	}
+	parsedName, err := modconfig.ParseResourceName(target)
+	if err != nil {
+		return err
+	}
+	if parsedName.ItemType == modconfig.BlockTypeBenchmark {
+		return s.runScheduledCheck(ctx, schedule, target, outputDir, runTime)
+	}
+	return s.runScheduledDashboard(ctx, schedule, target, outputDir, runTime)
+}
+
+// runScheduledDashboard executes the dashboard and writes the execution complete payload as a json snapshot
+func (s *Server) runScheduledDashboard(ctx context.Context, schedule *modconfig.Schedule, target, outputDir string, runTime time.Time) error {
+	inputs := make(map[string]interface{}, len(schedule.InputValues))
+	for name, value := range schedule.InputValues {
+		inputs[name] = value
+	}
+	if inputErrors := s.validateInputValues(target, inputs); len(inputErrors) > 0 {
+		return fmt.Errorf("invalid input values: %v", inputErrors)
+	}
+
+	// scheduled dashboard runs are tracked as REST API executions, so their results are also available from the API
+	execution := s.startApiExecution(target, inputs)
+	select {
+	case <-execution.done:
+	case <-ctx.Done():
+		s.cancelApiExecution(s.context, execution.ExecutionId)
+		return ctx.Err()
+	}
+
+	res := s.getApiExecution(execution.ExecutionId)
+	if res == nil {
+		return fmt.Errorf("execution was cancelled")
+	}
+	if len(res.Result) == 0 {
+		return fmt.Errorf("%s", res.Error)
+	}
+
+	var snapshot bytes.Buffer
+	if err := json.Indent(&snapshot, res.Result, "", "  "); err != nil {
+		return err
+	}
+	return os.WriteFile(scheduleOutputFile(outputDir, target, runTime, ".json"), snapshot.Bytes(), 0644)
+}
+
+// runScheduledCheck runs the benchmark and writes the results in each of the export formats of the schedule
+func (s *Server) runScheduledCheck(ctx context.Context, schedule *modconfig.Schedule, target, outputDir string, runTime time.Time) error {
+	executionTree, err := controlexecute.NewExecutionTree(ctx, s.workspace, s.dbClient, target)
+	if err != nil {
+		return err
+	}
+	executionTree.Execute(ctx)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	for _, format := range schedule.GetExportFormats() {
+		formatter, _, err := controldisplay.GetTemplateExportFormatter(format, false)
+		if err != nil {
+			return err
+		}
+		reader, err := formatter.Format(ctx, executionTree)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(scheduleOutputFile(outputDir, target, runTime, formatter.FileExtension()), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scheduleOutputDir(schedule *modconfig.Schedule) (string, error) {
+	outputDir := typehelpers.SafeString(schedule.OutputDir)
+	if outputDir == "" {
+		return filepaths.EnsureDashboardScheduleDir(schedule.Name), nil
+	}
+	return outputDir, os.MkdirAll(outputDir, 0755)
+}
+
+func scheduleOutputFile(outputDir, target string, runTime time.Time, extension string) string {
+	return filepath.Join(outputDir, fmt.Sprintf("%s_%s%s", target, runTime.UTC().Format(scheduleTimeFormat), extension))
+}
+
+// applyScheduleRetention removes the output files of all but the most recent 'retention' runs of the target
+// all files of a run share the same run time, whatever their format
+func applyScheduleRetention(outputDir, target string, retention int) error {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return err
+	}
+
+	prefix := target + "_"
+	runFiles := make(map[string][]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || len(name) < len(prefix)+len(scheduleTimeFormat) {
+			continue
+		}
+		runTime := name[len(prefix) : len(prefix)+len(scheduleTimeFormat)]
+		if _, err := time.Parse(scheduleTimeFormat, runTime); err != nil {
+			continue
+		}
+		runFiles[runTime] = append(runFiles[runTime], name)
+	}
+	if len(runFiles) <= retention {
+		return nil
+	}
+
+	runTimes := make([]string, 0, len(runFiles))
+	for runTime := range runFiles {
+		runTimes = append(runTimes, runTime)
+	}
+	sort.Strings(runTimes)
+	for _, runTime := range runTimes[:len(runTimes)-retention] {
+		for _, name := range runFiles[runTime] {
+			if err := os.Remove(filepath.Join(outputDir, name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package dashboardserver
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestApplyScheduleRetention(t *testing.T) {
+	outputDir := t.TempDir()
+	target := "mod.benchmark.cis"
+	start := time.Date(2022, 6, 1, 2, 0, 0, 0, time.UTC)
+
+	// 4 runs, each exported in 2 formats
+	for i := 0; i < 4; i++ {
+		runTime := start.AddDate(0, 0, i)
+		for _, extension := range []string{".json", ".html"} {
+			if err := os.WriteFile(scheduleOutputFile(outputDir, target, runTime, extension), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// files of other targets and unrelated files are not touched
+	otherFiles := []string{
+		filepath.Base(scheduleOutputFile(outputDir, "mod.dashboard.iam", start, ".json")),
+		target + "_notes.txt",
+	}
+	for _, name := range otherFiles {
+		if err := os.WriteFile(filepath.Join(outputDir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := applyScheduleRetention(outputDir, target, 2); err != nil {
+		t.Fatalf("Test: 'retention' FAILED : unexpected error %v", err)
+	}
+
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	expected := []string{
+		filepath.Base(scheduleOutputFile(outputDir, target, start.AddDate(0, 0, 2), ".html")),
+		filepath.Base(scheduleOutputFile(outputDir, target, start.AddDate(0, 0, 2), ".json")),
+		filepath.Base(scheduleOutputFile(outputDir, target, start.AddDate(0, 0, 3), ".html")),
+		filepath.Base(scheduleOutputFile(outputDir, target, start.AddDate(0, 0, 3), ".json")),
+	}
+	expected = append(expected, otherFiles...)
+	sort.Strings(expected)
+	sort.Strings(names)
+
+	if len(names) != len(expected) {
+		t.Fatalf("Test: 'retention' FAILED : expected files %v, got %v", expected, names)
+	}
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Errorf("Test: 'retention' FAILED : expected files %v, got %v", expected, names)
+			break
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package dashboardserver
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/constants"
+	"github.com/turbot/steampipe/db/db_local"
+)
+
+type AuthType string
+
+const (
+	// AuthTypeNone performs no authentication
+	AuthTypeNone AuthType = "none"
+	// AuthTypeBasic requires HTTP basic auth credentials
+	AuthTypeBasic AuthType = "basic"
+	// AuthTypeToken requires a static bearer token
+	AuthTypeToken AuthType = "token"
+	// AuthTypeProxy trusts a user header set by an authenticating (e.g. OIDC) reverse proxy
+	AuthTypeProxy AuthType = "proxy"
+)
+
+// IsValid is a validator for AuthType known values
+func (at AuthType) IsValid() error {
+	switch at {
+	case AuthTypeNone, AuthTypeBasic, AuthTypeToken, AuthTypeProxy:
+		return nil
+	}
+	return fmt.Errorf("invalid dashboard auth type. Must be one of '%v', '%v', '%v' or '%v'", AuthTypeNone, AuthTypeBasic, AuthTypeToken, AuthTypeProxy)
+}
+
+// the cookie used to remember a token passed as a query parameter
+// this is needed as the browser cannot add an Authorization header to the websocket upgrade request
+const tokenCookieName = "steampipe_dashboard_token"
+
+// serverSecurity contains the authentication and TLS configuration of the dashboard server
+type serverSecurity struct {
+	// middleware which authenticates every request - nil if authentication is disabled
+	auth        gin.HandlerFunc
+	tlsCertFile string
+	tlsKeyFile  string
+}
+
+func (s *serverSecurity) tlsEnabled() bool {
+	return s.tlsCertFile != ""
+}
+
+// newServerSecurity builds the server security configuration from the dashboard auth and TLS args
+func newServerSecurity() (*serverSecurity, error) {
+	security := &serverSecurity{}
+
+	auth, err := newAuthMiddleware(AuthType(viper.GetString(constants.ArgDashboardAuth)))
+	if err != nil {
+		return nil, err
+	}
+	security.auth = auth
+
+	security.tlsCertFile, security.tlsKeyFile, err = getTLSFiles()
+	if err != nil {
+		return nil, err
+	}
+	return security, nil
+}
+
+// getTLSFiles returns the certificate and key files to serve TLS with
+// user supplied files take precedence - otherwise, if TLS is enabled, the steampipe self-signed certificate is used
+func getTLSFiles() (certFile, keyFile string, err error) {
+	certFile = viper.GetString(constants.ArgDashboardTLSCert)
+	keyFile = viper.GetString(constants.ArgDashboardTLSKey)
+	if (certFile == "") != (keyFile == "") {
+		return "", "", fmt.Errorf("--%s and --%s must be specified together", constants.ArgDashboardTLSCert, constants.ArgDashboardTLSKey)
+	}
+	if certFile != "" {
+		for _, f := range []string{certFile, keyFile} {
+			if _, err := os.Stat(f); err != nil {
+				return "", "", fmt.Errorf("could not read dashboard TLS file: %s", err.Error())
+			}
+		}
+		return certFile, keyFile, nil
+	}
+	if !viper.GetBool(constants.ArgDashboardTLS) {
+		return "", "", nil
+	}
+	certFile, keyFile, err = db_local.EnsureServerCertificate()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create self-signed certificate for the dashboard server: %s", err.Error())
+	}
+	return certFile, keyFile, nil
+}
+
+func newAuthMiddleware(authType AuthType) (gin.HandlerFunc, error) {
+	if authType == "" {
+		authType = AuthTypeNone
+	}
+	if err := authType.IsValid(); err != nil {
+		return nil, err
+	}
+
+	switch authType {
+	case AuthTypeBasic:
+		username, password := os.Getenv(constants.EnvDashboardUsername), os.Getenv(constants.EnvDashboardPassword)
+		if username == "" || password == "" {
+			return nil, fmt.Errorf("dashboard basic auth requires %s and %s to be set", constants.EnvDashboardUsername, constants.EnvDashboardPassword)
+		}
+		return basicAuth(username, password), nil
+	case AuthTypeToken:
+		token := os.Getenv(constants.EnvDashboardToken)
+		if token == "" {
+			return nil, fmt.Errorf("dashboard token auth requires %s to be set", constants.EnvDashboardToken)
+		}
+		return tokenAuth(token), nil
+	case AuthTypeProxy:
+		header := viper.GetString(constants.ArgDashboardAuthHeader)
+		if header == "" {
+			header = constants.DashboardDefaultAuthHeader
+		}
+		return proxyAuth(header), nil
+	}
+	return nil, nil
+}
+
+func basicAuth(username, password string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, pass, ok := c.Request.BasicAuth()
+		if !ok || !secureEquals(user, username) || !secureEquals(pass, password) {
+			c.Header("WWW-Authenticate", `Basic realm="Steampipe Dashboard"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
+
+// tokenAuth accepts the token as a bearer token, a 'token' query parameter or a cookie
+// a token passed as a query parameter is stored in a cookie, so that links of the form
+// https://host:9194/?token=xxx authenticate the UI and its websocket connection
+func tokenAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if bearer, ok := bearerToken(c.GetHeader("Authorization")); ok && secureEquals(bearer, token) {
+			c.Next()
+			return
+		}
+		if queryToken := c.Query("token"); secureEquals(queryToken, token) {
+			c.SetSameSite(http.SameSiteStrictMode)
+			c.SetCookie(tokenCookieName, queryToken, 0, "/", "", c.Request.TLS != nil, true)
+			c.Next()
+			return
+		}
+		if cookieToken, err := c.Cookie(tokenCookieName); err == nil && secureEquals(cookieToken, token) {
+			c.Next()
+			return
+		}
+		c.AbortWithStatus(http.StatusUnauthorized)
+	}
+}
+
+// bearerToken returns the token of an 'Authorization: Bearer <token>' header
+// the scheme is case-insensitive - a header with any other scheme, or none, is not a bearer token
+func bearerToken(header string) (string, bool) {
+	const scheme = "bearer "
+	if len(header) <= len(scheme) || !strings.EqualFold(header[:len(scheme)], scheme) {
+		return "", false
+	}
+	return header[len(scheme):], true
+}
+
+// proxyAuth trusts the user header set by an authenticating reverse proxy
+// NOTE: this is only safe if the server cannot be reached other than through the proxy
+func proxyAuth(header string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.GetHeader(header)
+		if user == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		log.Printf("[TRACE] dashboard request from proxy user %s: %s", user, c.Request.URL.Path)
+		c.Next()
+	}
+}
+
+// secureEquals compares credentials in constant time
+func secureEquals(actual, expected string) bool {
+	return actual != "" && subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) == 1
+}
+
+// IsTLSEnabled returns whether the dashboard args enable TLS, either using user supplied or self-signed certificates
+func IsTLSEnabled() bool {
+	return viper.GetBool(constants.ArgDashboardTLS) || viper.GetString(constants.ArgDashboardTLSCert) != ""
+}
+
+// DashboardUrl returns the url of a dashboard server running on localhost
+func DashboardUrl(port int, tlsEnabled bool) string {
+	scheme := "http"
+	if tlsEnabled {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://localhost:%d", scheme, port)
+}
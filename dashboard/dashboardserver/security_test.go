@@ -0,0 +1,96 @@
+package dashboardserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type authTest struct {
+	auth           gin.HandlerFunc
+	setup          func(r *http.Request)
+	expectedStatus int
+}
+
+var testCasesAuth = map[string]authTest{
+	"basic valid": {
+		auth:           basicAuth("user", "secret"),
+		setup:          func(r *http.Request) { r.SetBasicAuth("user", "secret") },
+		expectedStatus: http.StatusOK,
+	},
+	"basic wrong password": {
+		auth:           basicAuth("user", "secret"),
+		setup:          func(r *http.Request) { r.SetBasicAuth("user", "wrong") },
+		expectedStatus: http.StatusUnauthorized,
+	},
+	"basic missing": {
+		auth:           basicAuth("user", "secret"),
+		expectedStatus: http.StatusUnauthorized,
+	},
+	"token bearer": {
+		auth:           tokenAuth("abc"),
+		setup:          func(r *http.Request) { r.Header.Set("Authorization", "Bearer abc") },
+		expectedStatus: http.StatusOK,
+	},
+	"token query": {
+		auth:           tokenAuth("abc"),
+		setup:          func(r *http.Request) { r.URL.RawQuery = "token=abc" },
+		expectedStatus: http.StatusOK,
+	},
+	"token cookie": {
+		auth:           tokenAuth("abc"),
+		setup:          func(r *http.Request) { r.AddCookie(&http.Cookie{Name: tokenCookieName, Value: "abc"}) },
+		expectedStatus: http.StatusOK,
+	},
+	"token bearer lower case scheme": {
+		auth:           tokenAuth("abc"),
+		setup:          func(r *http.Request) { r.Header.Set("Authorization", "bearer abc") },
+		expectedStatus: http.StatusOK,
+	},
+	"token without bearer scheme": {
+		auth:           tokenAuth("abc"),
+		setup:          func(r *http.Request) { r.Header.Set("Authorization", "abc") },
+		expectedStatus: http.StatusUnauthorized,
+	},
+	"token wrong": {
+		auth:           tokenAuth("abc"),
+		setup:          func(r *http.Request) { r.Header.Set("Authorization", "Bearer abd") },
+		expectedStatus: http.StatusUnauthorized,
+	},
+	"token empty bearer": {
+		auth:           tokenAuth("abc"),
+		setup:          func(r *http.Request) { r.Header.Set("Authorization", "Bearer ") },
+		expectedStatus: http.StatusUnauthorized,
+	},
+	"proxy header": {
+		auth:           proxyAuth("X-Forwarded-User"),
+		setup:          func(r *http.Request) { r.Header.Set("X-Forwarded-User", "alice") },
+		expectedStatus: http.StatusOK,
+	},
+	"proxy missing header": {
+		auth:           proxyAuth("X-Forwarded-User"),
+		expectedStatus: http.StatusUnauthorized,
+	},
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for name, test := range testCasesAuth {
+		router := gin.New()
+		router.Use(test.auth)
+		router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.setup != nil {
+			test.setup(req)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test: '%s'' FAILED : expected status %d, got %d", name, test.expectedStatus, w.Code)
+		}
+	}
+}
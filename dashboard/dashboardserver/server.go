@@ -9,12 +9,16 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/turbot/go-kit/helpers"
 	typeHelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/constants"
 	"github.com/turbot/steampipe/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/dashboard/dashboardexecute"
 	"github.com/turbot/steampipe/db/db_common"
+	"github.com/turbot/steampipe/steampipeconfig"
 	"github.com/turbot/steampipe/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/workspace"
 	"gopkg.in/olahol/melody.v1"
@@ -25,6 +29,11 @@ func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Work
 
 	OutputWait(ctx, "Starting Dashboard Server")
 
+	security, err := newServerSecurity()
+	if err != nil {
+		return nil, err
+	}
+
 	webSocket := melody.New()
 
 	var dashboardClients = make(map[string]*DashboardClientInfo)
@@ -38,10 +47,16 @@ func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Work
 		dashboardClients: dashboardClients,
 		webSocket:        webSocket,
 		workspace:        w,
+		security:         security,
+		apiExecutions:    make(map[string]*ApiExecution),
+	}
+
+	if ttl := viper.GetInt(constants.ArgDashboardCacheTTL); ttl > 0 {
+		server.executionCache = newExecutionCache(time.Duration(ttl) * time.Second)
 	}
 
 	w.RegisterDashboardEventHandler(server.HandleWorkspaceUpdate)
-	err := w.SetupWatcher(ctx, dbClient, func(c context.Context, e error) {})
+	err = w.SetupWatcher(ctx, dbClient, func(c context.Context, e error) {})
 	OutputMessage(ctx, "Workspace loaded")
 
 	return server, err
@@ -76,7 +91,13 @@ func getDashboardsInterestedInResourceChanges(dashboardsBeingWatched []string, e
 // it returns a channel which is signalled when the API server terminates
 func (s *Server) Start() chan struct{} {
 	s.initAsync(s.context)
-	return startAPIAsync(s.context, s.webSocket)
+	// schedules are only run by the dashboard server started by the service
+	if viper.GetBool(constants.ArgServiceMode) && steampipeconfig.GlobalConfig != nil {
+		if schedules := steampipeconfig.GlobalConfig.ScheduleList(); len(schedules) > 0 {
+			s.runSchedules(s.context, schedules)
+		}
+	}
+	return startAPIAsync(s.context, s.webSocket, s.security, s.addRestRoutes)
 }
 
 // Shutdown stops the API server
@@ -136,6 +157,12 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 		}
 
 		s.writePayloadToSession(e.Session, payload)
+		if isApiSession(e.Session) {
+			s.setApiExecutionError(e.Session, e.Error)
+		}
+		if s.executionCache != nil {
+			s.executionCache.setError(e.Session)
+		}
 		outputError(s.context, e.Error)
 
 	case *dashboardevents.ExecutionComplete:
@@ -146,6 +173,13 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 		}
 		dashboardName := e.Root.GetName()
 		s.writePayloadToSession(e.Session, payload)
+		if s.executionCache != nil {
+			s.executionCache.setComplete(e.Session)
+		}
+		if isApiSession(e.Session) {
+			// the execution tree is retained until the execution is evicted, so panel data may be downloaded
+			s.setApiExecutionComplete(e, payload)
+		}
 		outputReady(s.context, fmt.Sprintf("Execution complete: %s", dashboardName))
 
 	case *dashboardevents.LeafNodeError:
@@ -211,6 +245,11 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 			return
 		}
 
+		// the workspace has changed, so previous executions must not be reused
+		if s.executionCache != nil {
+			s.cancelSharedExecutions(s.context, s.executionCache.invalidate())
+		}
+
 		for k, v := range s.dashboardClients {
 			log.Printf("[TRACE] Dashboard client: %v %v\n", k, typeHelpers.SafeString(v.Dashboard))
 		}
@@ -266,7 +305,7 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 			for sessionId, dashboardClientInfo := range sessionMap {
 				if typeHelpers.SafeString(dashboardClientInfo.Dashboard) == changedDashboardName {
 					// 					outputMessage(s.context, fmt.Sprintf("Dashboard Changed - executing with inputs: %v", dashboardClientInfo.DashboardInputs))
-					_ = dashboardexecute.Executor.ExecuteDashboard(s.context, sessionId, changedDashboardName, dashboardClientInfo.DashboardInputs, s.workspace, s.dbClient)
+					s.executeDashboard(s.context, sessionId, changedDashboardName, dashboardClientInfo.DashboardInputs)
 				}
 			}
 		}
@@ -286,7 +325,7 @@ func (s *Server) HandleWorkspaceUpdate(event dashboardevents.DashboardEvent) {
 			for sessionId, dashboardClientInfo := range sessionMap {
 				if typeHelpers.SafeString(dashboardClientInfo.Dashboard) == newDashboardName {
 					// 					outputMessage(s.context, fmt.Sprintf("New Dashboard - executing with inputs: %v", dashboardClientInfo.DashboardInputs))
-					_ = dashboardexecute.Executor.ExecuteDashboard(s.context, sessionId, newDashboardName, dashboardClientInfo.DashboardInputs, s.workspace, s.dbClient)
+					s.executeDashboard(s.context, sessionId, newDashboardName, dashboardClientInfo.DashboardInputs)
 				}
 			}
 		}
@@ -366,19 +405,88 @@ func (s *Server) handleMessageFunc(ctx context.Context) func(session *melody.Ses
 			}
 			_ = session.Write(payload)
 		case "select_dashboard":
-			s.setDashboardForSession(sessionId, request.Payload.Dashboard.FullName, request.Payload.InputValues)
-			_ = dashboardexecute.Executor.ExecuteDashboard(ctx, sessionId, request.Payload.Dashboard.FullName, request.Payload.InputValues, s.workspace, s.dbClient)
+			dashboardName := request.Payload.Dashboard.FullName
+			// input values in the dashboard url are used unless the client has provided a value
+			initialInputs := inputsFromQueryString(request.Payload.Search, dashboardName, s.workspace.GetResourceMaps())
+			inputs := mergeInputs(initialInputs, request.Payload.InputValues)
+			s.setDashboardForSession(sessionId, dashboardName, inputs)
+			if s.writeInputValidationErrors(session, dashboardName, inputs) {
+				return
+			}
+			s.executeDashboard(ctx, sessionId, dashboardName, inputs)
 		case "input_changed":
+			if dashboardClientInfo, ok := s.getDashboardClients()[sessionId]; ok && dashboardClientInfo.Dashboard != nil {
+				if s.writeInputValidationErrors(session, *dashboardClientInfo.Dashboard, request.Payload.InputValues) {
+					return
+				}
+			}
 			s.setDashboardInputsForSession(sessionId, request.Payload.InputValues)
-			_ = dashboardexecute.Executor.OnInputChanged(ctx, sessionId, request.Payload.InputValues, request.Payload.ChangedInput)
+			if s.executionCache != nil {
+				s.onSharedInputChanged(ctx, sessionId, request.Payload.InputValues, request.Payload.ChangedInput)
+			} else {
+				_ = dashboardexecute.Executor.OnInputChanged(ctx, sessionId, request.Payload.InputValues, request.Payload.ChangedInput)
+			}
+		case "cancel_panel":
+			executionId, ok := s.getExecutionIdForPanelChange(sessionId, true)
+			if !ok {
+				log.Printf("[TRACE] not cancelling panel %s - the execution is shared with other sessions", request.Payload.Panel)
+				return
+			}
+			if err := dashboardexecute.Executor.CancelPanel(executionId, request.Payload.Panel); err != nil {
+				log.Printf("[TRACE] failed to cancel panel %s: %s", request.Payload.Panel, err.Error())
+			}
+		case "rerun_panel":
+			executionId, ok := s.getExecutionIdForPanelChange(sessionId, false)
+			if !ok {
+				return
+			}
+			if err := dashboardexecute.Executor.RerunPanel(ctx, executionId, request.Payload.Panel); err != nil {
+				log.Printf("[TRACE] failed to re-run panel %s: %s", request.Payload.Panel, err.Error())
+			}
+		case "get_panel_data":
+			payload, err := buildPanelDataPayload(s.getExecutionIdForSession(sessionId), request.Payload.Panel, request.Payload.Format)
+			if err != nil {
+				panic(fmt.Errorf("error building payload for get_panel_data: %v", err))
+			}
+			_ = session.Write(payload)
 		case "clear_dashboard":
 			s.setDashboardInputsForSession(sessionId, nil)
-			dashboardexecute.Executor.CancelExecutionForSession(ctx, sessionId)
+			s.cancelExecutionForSession(ctx, sessionId)
 		}
 
 	}
 }
 
+// writeInputValidationErrors validates the input values for the dashboard
+// if any are invalid, the validation errors are written to the session and true is returned
+func (s *Server) writeInputValidationErrors(session *melody.Session, dashboardName string, inputs map[string]interface{}) bool {
+	inputErrors := s.validateInputValues(dashboardName, inputs)
+	if len(inputErrors) == 0 {
+		return false
+	}
+	log.Printf("[TRACE] invalid input values for %s: %v", dashboardName, inputErrors)
+	payload, err := buildInputValidationErrorPayload(dashboardName, inputErrors)
+	if err != nil {
+		panic(fmt.Errorf("error building payload for input_validation_error: %v", err))
+	}
+	_ = session.Write(payload)
+	return true
+}
+
+// validateInputValues validates the input values against the inputs of the dashboard
+// it returns the validation error messages keyed by input name - benchmarks have no inputs, so are not validated
+func (s *Server) validateInputValues(dashboardName string, inputs map[string]interface{}) map[string]string {
+	dashboard, ok := s.workspace.GetResourceMaps().Dashboards[dashboardName]
+	if !ok {
+		return nil
+	}
+	inputErrors := make(map[string]string)
+	for name, err := range dashboard.ValidateInputValues(inputs) {
+		inputErrors[name] = err.Error()
+	}
+	return inputErrors
+}
+
 func (s *Server) clearSession(ctx context.Context, session *melody.Session) {
 	if strings.ToUpper(os.Getenv("DEBUG")) == "TRUE" {
 		return
@@ -386,11 +494,87 @@ func (s *Server) clearSession(ctx context.Context, session *melody.Session) {
 
 	sessionId := s.getSessionId(session)
 
-	dashboardexecute.Executor.CancelExecutionForSession(ctx, sessionId)
+	s.cancelExecutionForSession(ctx, sessionId)
 
 	s.deleteDashboardClient(sessionId)
 }
 
+// executeDashboard executes the dashboard for the client session
+// if the execution cache is enabled, the session shares any existing execution of the dashboard with the same inputs
+func (s *Server) executeDashboard(ctx context.Context, sessionId, dashboardName string, inputs map[string]interface{}) {
+	if s.executionCache == nil {
+		_ = dashboardexecute.Executor.ExecuteDashboard(ctx, sessionId, dashboardName, inputs, s.workspace, s.dbClient)
+		return
+	}
+
+	dashboardClientInfo, ok := s.getDashboardClients()[sessionId]
+	if !ok {
+		return
+	}
+	executionId, unusedExecutionIds := s.executionCache.join(sessionId, dashboardClientInfo.Session, dashboardName, inputs)
+	s.cancelSharedExecutions(ctx, unusedExecutionIds)
+	if executionId != "" {
+		_ = dashboardexecute.Executor.ExecuteDashboard(ctx, executionId, dashboardName, inputs, s.workspace, s.dbClient)
+	}
+}
+
+// onSharedInputChanged handles an input change for a session using a shared execution
+// as other sessions may be viewing the execution, the session moves to the execution for its new inputs
+func (s *Server) onSharedInputChanged(ctx context.Context, sessionId string, inputs map[string]interface{}, changedInput string) {
+	executionId, ok := s.executionCache.getExecutionId(sessionId)
+	dashboardClientInfo, clientOk := s.getDashboardClients()[sessionId]
+	if !ok || !clientOk || dashboardClientInfo.Dashboard == nil {
+		return
+	}
+
+	// clear any inputs depending on the changed input - only this session is notified
+	if clearedEvent := dashboardexecute.Executor.ClearDependentInputs(executionId, inputs, changedInput); clearedEvent != nil {
+		payload, err := buildInputValuesClearedPayload(clearedEvent)
+		if err != nil {
+			panic(fmt.Errorf("error building payload for input_values_cleared: %v", err))
+		}
+		s.writePayloadToSession(sessionId, payload)
+	}
+	s.executeDashboard(ctx, sessionId, *dashboardClientInfo.Dashboard, inputs)
+}
+
+// cancelExecutionForSession cancels the execution of the client session
+// a shared execution is only cancelled once no other sessions are using it
+func (s *Server) cancelExecutionForSession(ctx context.Context, sessionId string) {
+	if s.executionCache == nil {
+		dashboardexecute.Executor.CancelExecutionForSession(ctx, sessionId)
+		return
+	}
+	s.cancelSharedExecutions(ctx, s.executionCache.leave(sessionId))
+}
+
+// getExecutionIdForSession returns the id of the execution the session is viewing
+// NOTE: use getExecutionIdForPanelChange to alter the results of the execution
+func (s *Server) getExecutionIdForSession(sessionId string) string {
+	if s.executionCache != nil {
+		executionId, _ := s.executionCache.getExecutionId(sessionId)
+		return executionId
+	}
+	return sessionId
+}
+
+// getExecutionIdForPanelChange returns the id of the execution whose panel the session is cancelling or re-running
+// a shared execution is no longer reused by sessions which select the dashboard later, as its results are altered
+// if exclusive is set, false is returned if the execution is shared with other sessions
+func (s *Server) getExecutionIdForPanelChange(sessionId string, exclusive bool) (string, bool) {
+	if s.executionCache != nil {
+		return s.executionCache.detach(sessionId, exclusive)
+	}
+	return sessionId, true
+}
+
+func (s *Server) cancelSharedExecutions(ctx context.Context, executionIds []string) {
+	for _, executionId := range executionIds {
+		log.Printf("[TRACE] cancelling unused shared execution %s", executionId)
+		dashboardexecute.Executor.CancelExecutionForSession(ctx, executionId)
+	}
+}
+
 func (s *Server) addSession(session *melody.Session) {
 	sessionId := s.getSessionId(session)
 
@@ -428,6 +612,11 @@ func (s *Server) setDashboardForSession(sessionId string, dashboardName string,
 }
 
 func (s *Server) writePayloadToSession(sessionId string, payload []byte) {
+	// payloads for shared executions are sent to every session using the execution
+	if s.executionCache != nil && s.executionCache.publish(sessionId, payload) {
+		return
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -34,6 +34,7 @@ type DashboardServiceState struct {
 	Port          int          `json:"port"`
 	ListenType    string       `json:"listen_type"`
 	Listen        []string     `json:"listen"`
+	TLS           bool         `json:"tls"`
 	StructVersion int64        `json:"struct_version"`
 }
 
@@ -129,6 +130,7 @@ func RunForService(ctx context.Context, serverListen ListenType, serverPort List
 
 	utils.FailOnError(serverPort.IsValid())
 	utils.FailOnError(serverListen.IsValid())
+	utils.FailOnError(AuthType(viper.GetString(constants.ArgDashboardAuth)).IsValid())
 
 	// NOTE: args must be specified <arg>=<arg val>, as each entry in this array is a separate arg passed to cobra
 	args := []string{
@@ -139,6 +141,15 @@ func RunForService(ctx context.Context, serverListen ListenType, serverPort List
 		fmt.Sprintf("--%s=%s", constants.ArgWorkspaceChDir, viper.GetString(constants.ArgWorkspaceChDir)),
 		fmt.Sprintf("--%s=true", constants.ArgServiceMode),
 		fmt.Sprintf("--%s=false", constants.ArgInput),
+		fmt.Sprintf("--%s=%d", constants.ArgDashboardCacheTTL, viper.GetInt(constants.ArgDashboardCacheTTL)),
+		fmt.Sprintf("--%s=%d", constants.ArgDashboardQueryTimeout, viper.GetInt(constants.ArgDashboardQueryTimeout)),
+		fmt.Sprintf("--%s=%d", constants.ArgDashboardMaxParallel, viper.GetInt(constants.ArgDashboardMaxParallel)),
+		fmt.Sprintf("--%s=%d", constants.ArgDashboardServerMaxParallel, viper.GetInt(constants.ArgDashboardServerMaxParallel)),
+		fmt.Sprintf("--%s=%t", constants.ArgDashboardTLS, viper.GetBool(constants.ArgDashboardTLS)),
+		fmt.Sprintf("--%s=%s", constants.ArgDashboardTLSCert, viper.GetString(constants.ArgDashboardTLSCert)),
+		fmt.Sprintf("--%s=%s", constants.ArgDashboardTLSKey, viper.GetString(constants.ArgDashboardTLSKey)),
+		fmt.Sprintf("--%s=%s", constants.ArgDashboardAuth, viper.GetString(constants.ArgDashboardAuth)),
+		fmt.Sprintf("--%s=%s", constants.ArgDashboardAuthHeader, viper.GetString(constants.ArgDashboardAuthHeader)),
 	}
 
 	for _, variableArg := range viper.GetStringSlice(constants.ArgVariable) {
@@ -152,6 +163,7 @@ func RunForService(ctx context.Context, serverListen ListenType, serverPort List
 		self,
 		args...,
 	)
+	// NOTE: the dashboard credentials are passed to the server in the environment
 	cmd.Env = os.Environ()
 
 	// set group pgid attributes on the command to ensure the process is not shutdown when its parent terminates
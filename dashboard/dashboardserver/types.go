@@ -2,6 +2,7 @@ package dashboardserver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -47,6 +48,13 @@ type Server struct {
 	dashboardClients map[string]*DashboardClientInfo
 	webSocket        *melody.Melody
 	workspace        *workspace.Workspace
+	security         *serverSecurity
+	// shares executions between sessions - nil if the execution cache is disabled
+	executionCache *executionCache
+
+	// executions started using the REST API, keyed by execution id
+	apiExecutions    map[string]*ApiExecution
+	apiExecutionLock sync.Mutex
 }
 
 type ErrorPayload struct {
@@ -92,6 +100,21 @@ type InputValuesClearedPayload struct {
 	ExecutionId   string   `json:"execution_id"`
 }
 
+type InputValidationErrorPayload struct {
+	Action    string            `json:"action"`
+	Dashboard string            `json:"dashboard"`
+	Errors    map[string]string `json:"errors"`
+}
+
+// PanelDataPayload contains the full data of a panel - for the csv format, Data is the csv text
+type PanelDataPayload struct {
+	Action string      `json:"action"`
+	Panel  string      `json:"panel"`
+	Format string      `json:"format"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
 type DashboardClientInfo struct {
 	Session         *melody.Session
 	Dashboard       *string
@@ -106,6 +129,12 @@ type ClientRequestPayload struct {
 	Dashboard    ClientRequestDashboardPayload `json:"dashboard"`
 	InputValues  map[string]interface{}        `json:"input_values"`
 	ChangedInput string                        `json:"changed_input"`
+	// the query string of the dashboard url, which may contain initial input values, e.g. "?input.region=us-east-1"
+	Search string `json:"search"`
+	// the name of the panel to cancel, re-run or get the data of
+	Panel string `json:"panel"`
+	// the format of the panel data - 'json' or 'csv'
+	Format string `json:"format"`
 }
 
 type ClientRequest struct {
@@ -155,3 +184,43 @@ type DashboardMetadataPayload struct {
 	Action   string            `json:"action"`
 	Metadata DashboardMetadata `json:"metadata"`
 }
+
+type ApiExecutionStatus string
+
+const (
+	ApiExecutionRunning  ApiExecutionStatus = "running"
+	ApiExecutionComplete ApiExecutionStatus = "complete"
+	ApiExecutionError    ApiExecutionStatus = "error"
+)
+
+// ApiExecution is the state of a dashboard or benchmark execution started using the REST API
+// once the execution is complete, Result contains the execution complete payload
+type ApiExecution struct {
+	ExecutionId string             `json:"execution_id"`
+	Dashboard   string             `json:"dashboard"`
+	Status      ApiExecutionStatus `json:"status"`
+	Error       string             `json:"error,omitempty"`
+	StartTime   time.Time          `json:"start_time"`
+	Result      json.RawMessage    `json:"result,omitempty"`
+
+	// closed when the execution completes or fails
+	done chan struct{}
+}
+
+type ApiExecutionRequest struct {
+	Inputs map[string]interface{} `json:"inputs"`
+}
+
+type ApiDashboardsResponse struct {
+	Dashboards map[string]ModAvailableDashboard `json:"dashboards"`
+}
+
+type ApiBenchmarksResponse struct {
+	Benchmarks map[string]ModAvailableBenchmark `json:"benchmarks"`
+}
+
+type ApiErrorResponse struct {
+	Error string `json:"error"`
+	// the validation errors of the request inputs, keyed by input name
+	InputErrors map[string]string `json:"input_errors,omitempty"`
+}
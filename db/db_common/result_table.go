@@ -0,0 +1,101 @@
+package db_common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	typeHelpers "github.com/turbot/go-kit/types"
+)
+
+// CreateResultTable creates a table in the public schema whose columns are defined by the 'column' tags of rowType
+// any existing table with the same name is replaced
+// unlike the introspection tables, the table is not temporary so it is visible to all sessions
+func CreateResultTable(ctx context.Context, session *DatabaseSession, tableName string, rowType interface{}) error {
+	tableName = fmt.Sprintf("public.%s", PgEscapeName(tableName))
+	sql := []string{
+		fmt.Sprintf("drop table if exists %s;", tableName),
+		fmt.Sprintf(`create table %s (
+%s
+);`, tableName, strings.Join(getColumnDefinitions(rowType), ",\n")),
+	}
+
+	if _, err := session.Connection.ExecContext(ctx, strings.Join(sql, "\n")); err != nil {
+		return fmt.Errorf("failed to create table %s: %v", tableName, err)
+	}
+	return ctx.Err()
+}
+
+// InsertResultTableRows inserts rows into a table created by CreateResultTable
+// the column values are read from the 'column' tags of each row, and passed as query parameters,
+// as the row values may contain arbitrary text (e.g. resource names and reasons returned by control queries)
+func InsertResultTableRows(ctx context.Context, session *DatabaseSession, tableName string, rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	tableName = fmt.Sprintf("public.%s", PgEscapeName(tableName))
+
+	tx, err := session.Connection.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to populate table %s: %v", tableName, err)
+	}
+	defer tx.Rollback()
+
+	for _, row := range rows {
+		values, columns, err := getColumnParams(row)
+		if err != nil {
+			return fmt.Errorf("failed to populate table %s: %v", tableName, err)
+		}
+		placeholders := make([]string, len(values))
+		for i := range values {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		}
+		insertSql := fmt.Sprintf(`insert into %s (%s) values(%s)`, tableName, strings.Join(columns, ","), strings.Join(placeholders, ","))
+		if _, err := tx.ExecContext(ctx, insertSql, values...); err != nil {
+			return fmt.Errorf("failed to populate table %s: %v", tableName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to populate table %s: %v", tableName, err)
+	}
+	return ctx.Err()
+}
+
+// use reflection to evaluate the column names and query parameter values from item - return as 2 separate arrays
+// the values are passed to the database as text, which postgres converts to the column type
+func getColumnParams(item interface{}) ([]interface{}, []string, error) {
+	item = helpers.DereferencePointer(item)
+	val := reflect.ValueOf(item)
+	t := reflect.TypeOf(item)
+
+	var values []interface{}
+	var columns []string
+	for i := 0; i < val.NumField(); i++ {
+		field := t.Field(i)
+		columnTag, ok := newColumnTag(field)
+		if !ok {
+			continue
+		}
+
+		value := helpers.DereferencePointer(val.Field(i).Interface())
+		if value == nil {
+			continue
+		}
+		if columnTag.ColumnType == "jsonb" {
+			jsonBytes, err := json.Marshal(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to convert column %s to json: %v", columnTag.Column, err)
+			}
+			value = string(jsonBytes)
+		} else {
+			value = typeHelpers.ToString(value)
+		}
+		values = append(values, value)
+		columns = append(columns, columnTag.Column)
+	}
+	return values, columns, nil
+}
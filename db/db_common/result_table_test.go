@@ -0,0 +1,36 @@
+package db_common
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testResultTableRow struct {
+	Resource string            `column:"resource,text"`
+	Count    int               `column:"count,integer"`
+	Tags     map[string]string `column:"tags,jsonb"`
+	Reason   *string           `column:"reason,text"`
+	Ignored  string
+}
+
+func TestGetColumnParams(t *testing.T) {
+	// values are returned as parameters, so must not be quoted or escaped
+	row := &testResultTableRow{
+		Resource: "bucket$steampipe_escape$); drop table foo; --",
+		Count:    3,
+		Tags:     map[string]string{"owner": "o'brien"},
+		Ignored:  "ignored",
+	}
+	values, columns, err := getColumnParams(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedColumns := []string{"resource", "count", "tags"}
+	expectedValues := []interface{}{"bucket$steampipe_escape$); drop table foo; --", "3", `{"owner":"o'brien"}`}
+	if !reflect.DeepEqual(columns, expectedColumns) {
+		t.Errorf("expected columns %v, got %v", expectedColumns, columns)
+	}
+	if !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("expected values %v, got %v", expectedValues, values)
+	}
+}
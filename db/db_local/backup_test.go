@@ -13,7 +13,11 @@ import (
 )
 
 func TestTrimBackups(t *testing.T) {
-	filepaths.SteampipeDir = "~/.steampipe"
+	// use a temporary steampipe directory - '~' is not expanded, so would create a '~' directory under the working directory
+	prevSteampipeDir := filepaths.SteampipeDir
+	filepaths.SteampipeDir = t.TempDir()
+	defer func() { filepaths.SteampipeDir = prevSteampipeDir }()
+
 	// create backups more than MaxBackups
 	backupDir := filepaths.EnsureBackupsDir()
 	filesCreated := []string{}
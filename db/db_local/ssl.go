@@ -30,6 +30,15 @@ func CertificatesExist() bool {
 	return helpers.FileExists(getRootCertLocation()) && helpers.FileExists(getServerCertLocation())
 }
 
+// EnsureServerCertificate generates the self-signed server certificate if necessary,
+// and returns the locations of the server certificate and private key
+func EnsureServerCertificate() (certFile, keyFile string, err error) {
+	if err := ensureSelfSignedCertificate(); err != nil {
+		return "", "", err
+	}
+	return getServerCertLocation(), getServerCertKeyLocation(), nil
+}
+
 // RemoveServerCertificate removes the server certificate certificates so it will be regenerated
 func RemoveServerCertificate() error {
 	utils.LogTime("db_local.RemoveServerCertificate start")
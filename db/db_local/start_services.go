@@ -89,6 +89,9 @@ func StartServices(ctx context.Context, port int, listen StartListenType, invoke
 		// this is to handle the upgrade edge case where a user has a service running of an earlier version of steampipe
 		// and upgrades to this version - we need to ensure we create the command schema
 		res.Error = ensureCommandSchema(ctx, rootClient)
+		if res.Error == nil {
+			res.Error = ensureCheckHistorySchema(ctx, rootClient)
+		}
 		res.Status = ServiceAlreadyRunning
 	}
 
@@ -250,6 +253,12 @@ func ensureService(ctx context.Context, databaseName string) error {
 	if err != nil {
 		return err
 	}
+
+	// ensure the db contains the check history schema
+	err = ensureCheckHistorySchema(ctx, rootClient)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -531,6 +540,52 @@ func ensureCommandSchema(ctx context.Context, rootClient *sql.DB) error {
 	return nil
 }
 
+// create the check history schema and tables (if they do not exist)
+// steampipe users may read, insert and delete history, but may not alter the tables
+func ensureCheckHistorySchema(ctx context.Context, rootClient *sql.DB) error {
+	schema := constants.CheckHistorySchema
+	runTable := fmt.Sprintf("%s.%s", schema, constants.CheckHistoryRunTable)
+	resultTable := fmt.Sprintf("%s.%s", schema, constants.CheckHistoryResultTable)
+
+	checkHistoryStatements := []string{
+		fmt.Sprintf("create schema if not exists %s;", schema),
+		fmt.Sprintf("comment on schema %s is 'steampipe check history';", schema),
+		fmt.Sprintf(`create table if not exists %s (
+	run_id text primary key,
+	target text not null,
+	start_time timestamptz not null,
+	end_time timestamptz not null,
+	ok integer not null,
+	alarm integer not null,
+	info integer not null,
+	skip integer not null,
+	error integer not null,
+	score double precision
+);`, runTable),
+		fmt.Sprintf(`create table if not exists %s (
+	run_id text not null references %s (run_id) on delete cascade,
+	control_id text not null,
+	control_title text,
+	severity text,
+	benchmarks text[],
+	resource text,
+	status text not null,
+	reason text,
+	dimensions jsonb
+);`, resultTable, runTable),
+		fmt.Sprintf("create index if not exists control_result_run_id_idx on %s (run_id);", resultTable),
+		fmt.Sprintf("grant usage on schema %s to %s;", schema, constants.DatabaseUsersRole),
+		fmt.Sprintf("grant select, insert, delete on %s, %s to %s;", runTable, resultTable, constants.DatabaseUsersRole),
+	}
+
+	for _, statement := range checkHistoryStatements {
+		if _, err := rootClient.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ensures that the 'steampipe_users' role has permissions to work with temporary tables
 // this is done during database installation, but we need to migrate current installations
 func ensureTempTablePermissions(ctx context.Context, databaseName string, rootClient *sql.DB) error {
@@ -33,6 +33,11 @@ func ColumnValuesAsString(values []interface{}, columns []*sql.ColumnType) ([]st
 
 // ColumnValueAsString converts column value to string
 func ColumnValueAsString(val interface{}, colType *sql.ColumnType) (result string, err error) {
+	return ColumnValueAsStringForType(val, colType.DatabaseTypeName())
+}
+
+// ColumnValueAsStringForType converts a column value to string, given the database type name of the column
+func ColumnValueAsStringForType(val interface{}, databaseTypeName string) (result string, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			result = fmt.Sprintf("%v", val)
@@ -45,7 +50,7 @@ func ColumnValueAsString(val interface{}, colType *sql.ColumnType) (result strin
 
 	//log.Printf("[TRACE] ColumnValueAsString type %s", colType.DatabaseTypeName())
 	// possible types for colType are defined in pq/oid/types.go
-	switch colType.DatabaseTypeName() {
+	switch databaseTypeName {
 	case "JSON", "JSONB":
 		bytes, err := json.Marshal(val)
 		if err != nil {
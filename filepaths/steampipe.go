@@ -45,6 +45,16 @@ func EnsureTemplateDir() string {
 	return ensureSteampipeSubDir(filepath.Join("check", "templates"))
 }
 
+// EnsureCheckResultCacheDir returns the path to the control result cache directory (creates if missing)
+func EnsureCheckResultCacheDir() string {
+	return ensureSteampipeSubDir(filepath.Join("check", "cache"))
+}
+
+// EnsureCheckAlarmStateDir returns the path to the directory of check notifier alarm state (creates if missing)
+func EnsureCheckAlarmStateDir() string {
+	return ensureSteampipeSubDir(filepath.Join("check", "alarms"))
+}
+
 // EnsurePluginDir returns the path to the plugins directory (creates if missing)
 func EnsurePluginDir() string {
 	return ensureSteampipeSubDir("plugins")
@@ -84,6 +94,11 @@ func EnsureDashboardAssetsDir() string {
 	return ensureSteampipeSubDir(filepath.Join("dashboard", "assets"))
 }
 
+// EnsureDashboardScheduleDir returns the path to the default output directory of a schedule (creates if missing)
+func EnsureDashboardScheduleDir(scheduleName string) string {
+	return ensureSteampipeSubDir(filepath.Join("dashboard", "schedules", scheduleName))
+}
+
 // LegacyDashboardAssetsDir returns the path to the legacy report assets folder
 func LegacyDashboardAssetsDir() string {
 	return steampipeSubDir("report")
@@ -173,6 +173,28 @@ func loadConfig(configFolder string, steampipeConfig *SteampipeConfig, opts *loa
 			// if options are already set, this will merge the new options over the top of the existing options
 			// i.e. new options have precedence
 			steampipeConfig.SetOptions(options)
+
+		case "notifier":
+			notifier, moreDiags := parse.DecodeNotifier(block)
+			if moreDiags.HasErrors() {
+				diags = append(diags, moreDiags...)
+				continue
+			}
+			if _, alreadyThere := steampipeConfig.Notifiers[notifier.Name]; alreadyThere {
+				return fmt.Errorf("duplicate notifier name: '%s' in '%s'", notifier.Name, block.TypeRange.Filename)
+			}
+			steampipeConfig.Notifiers[notifier.Name] = notifier
+
+		case "schedule":
+			schedule, moreDiags := parse.DecodeSchedule(block)
+			if moreDiags.HasErrors() {
+				diags = append(diags, moreDiags...)
+				continue
+			}
+			if _, alreadyThere := steampipeConfig.Schedules[schedule.Name]; alreadyThere {
+				return fmt.Errorf("duplicate schedule name: '%s' in '%s'", schedule.Name, block.TypeRange.Filename)
+			}
+			steampipeConfig.Schedules[schedule.Name] = schedule
 		}
 	}
 
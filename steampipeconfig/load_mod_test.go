@@ -238,6 +238,40 @@ func init() {
 				},
 			},
 		},
+		"controls_with_preconditions": {
+			source: "testdata/mods/controls_with_preconditions",
+			expected: &modconfig.Mod{
+				ShortName:   "m1",
+				FullName:    "mod.m1",
+				Title:       toStringPointer("M1"),
+				Description: toStringPointer("THIS IS M1"),
+				ResourceMaps: &modconfig.ModResources{
+					Controls: map[string]*modconfig.Control{
+						"m1.control.c1": {
+							ShortName:   "c1",
+							FullName:    "m1.control.c1",
+							Title:       toStringPointer("C1"),
+							Description: toStringPointer("THIS IS CONTROL 1"),
+							SQL:         toStringPointer("select 'ok' as status, 'foo' as resource, 'bar' as reason"),
+							Args:        &modconfig.QueryArgs{},
+						},
+						"m1.control.c2": {
+							ShortName:   "c2",
+							FullName:    "m1.control.c2",
+							Title:       toStringPointer("C2"),
+							Description: toStringPointer("THIS IS CONTROL 2"),
+							SQL:         toStringPointer("select 'ok' as status, 'foo' as resource, 'bar' as reason"),
+							Args:        &modconfig.QueryArgs{},
+							DependsOn:   []*modconfig.Control{{FullName: "m1.control.c1"}},
+							Precondition: &modconfig.ControlPrecondition{
+								SQL:    toStringPointer("select true"),
+								Reason: toStringPointer("C1 IS NOT ENABLED"),
+							},
+						},
+					},
+				},
+			},
+		},
 		"controls_and_groups": {
 			source: "testdata/mods/controls_and_groups",
 			expected: &modconfig.Mod{
@@ -338,6 +372,10 @@ func init() {
 			source:   "testdata/mods/controls_and_groups_circular",
 			expected: "ERROR",
 		},
+		"controls_with_circular_dependencies": {
+			source:   "testdata/mods/controls_with_circular_dependencies",
+			expected: "ERROR",
+		},
 		"controls_and_groups_duplicate_child": {
 			source:   "testdata/mods/controls_and_groups_duplicate_child",
 			expected: "ERROR",
@@ -29,6 +29,14 @@ type Control struct {
 	Severity         *string           `cty:"severity" hcl:"severity"  column:"severity,text"  json:"severity,omitempty"`
 	Tags             map[string]string `cty:"tags" hcl:"tags,optional"  column:"tags,jsonb"  json:"tags,omitempty"`
 	Title            *string           `cty:"title" hcl:"title"  column:"title,text"  json:"-"`
+	Weight           *int              `cty:"weight" hcl:"weight"  column:"weight,integer"  json:"weight,omitempty"`
+
+	Remediation *ControlRemediation `cty:"remediation" hcl:"remediation,block" column:"remediation,jsonb" json:"remediation,omitempty"`
+	Evidence    *ControlEvidence    `cty:"evidence" hcl:"evidence,block" column:"evidence,jsonb" json:"evidence,omitempty"`
+
+	// controls which must pass for this control to be run
+	DependsOn    []*Control           `hcl:"depends_on,optional" json:"-"`
+	Precondition *ControlPrecondition `cty:"precondition" hcl:"precondition,block" column:"precondition,jsonb" json:"precondition,omitempty"`
 
 	// QueryProvider
 	SQL                   *string     `cty:"sql" hcl:"sql" column:"sql,text" json:"-"`
@@ -75,7 +83,8 @@ func (c *Control) Equals(other *Control) bool {
 		typehelpers.SafeString(c.SearchPathPrefix) == typehelpers.SafeString(other.SearchPathPrefix) &&
 		typehelpers.SafeString(c.Severity) == typehelpers.SafeString(other.Severity) &&
 		typehelpers.SafeString(c.SQL) == typehelpers.SafeString(other.SQL) &&
-		typehelpers.SafeString(c.Title) == typehelpers.SafeString(other.Title)
+		typehelpers.SafeString(c.Title) == typehelpers.SafeString(other.Title) &&
+		utils.SafeIntEqual(c.Weight, other.Weight)
 	if !res {
 		return res
 	}
@@ -118,6 +127,36 @@ func (c *Control) Equals(other *Control) bool {
 		}
 	}
 
+	// remediation
+	if c.Remediation == nil {
+		if other.Remediation != nil {
+			return false
+		}
+	} else if !c.Remediation.Equals(other.Remediation) {
+		return false
+	}
+
+	// evidence
+	if c.Evidence == nil {
+		if other.Evidence != nil {
+			return false
+		}
+	} else if !c.Evidence.Equals(other.Evidence) {
+		return false
+	}
+
+	// preconditions
+	if c.Precondition == nil {
+		if other.Precondition != nil {
+			return false
+		}
+	} else if !c.Precondition.Equals(other.Precondition) {
+		return false
+	}
+	if strings.Join(c.DependsOnNames(), ",") != strings.Join(other.DependsOnNames(), ",") {
+		return false
+	}
+
 	// params
 	if len(c.Params) != len(other.Params) {
 		return false
@@ -252,8 +291,51 @@ func (c *Control) OnDecoded(block *hcl.Block, resourceMapProvider ModResourcesPr
 			Subject:  &c.DeclRange,
 		}}
 	}
+	if c.Weight != nil && *c.Weight < 0 {
+		return hcl.Diagnostics{&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has a negative weight - weight must be zero or greater", c.FullName),
+			Subject:  &c.DeclRange,
+		}}
+	}
 
-	return nil
+	return c.resolveDependsOn(resourceMapProvider)
+}
+
+// DependsOnNames returns the names of the controls this control depends on
+func (c *Control) DependsOnNames() []string {
+	var names []string
+	for _, dep := range c.DependsOn {
+		names = append(names, dep.Name())
+	}
+	return names
+}
+
+// the depends_on controls are decoded from their cty values, which do not contain all control properties
+// (e.g. the query args) - replace them with the controls from the resource map provider
+func (c *Control) resolveDependsOn(resourceMapProvider ModResourcesProvider) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for i, dep := range c.DependsOn {
+		resolved, ok := resolveBase(dep, resourceMapProvider)
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("%s depends on %s, which could not be resolved", c.FullName, dep.Name()),
+				Subject:  &c.DeclRange,
+			})
+			continue
+		}
+		if resolved.Name() == c.Name() {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("%s cannot depend on itself", c.FullName),
+				Subject:  &c.DeclRange,
+			})
+			continue
+		}
+		c.DependsOn[i] = resolved.(*Control)
+	}
+	return diags
 }
 
 // AddReference implements HclResource
@@ -360,6 +442,9 @@ func (c *Control) Diff(other *Control) *DashboardTreeItemDiffs {
 	if !utils.SafeStringsEqual(c.Severity, other.Severity) {
 		res.AddPropertyDiff("Severity")
 	}
+	if !utils.SafeIntEqual(c.Weight, other.Weight) {
+		res.AddPropertyDiff("Weight")
+	}
 	if len(c.Tags) != len(other.Tags) {
 		res.AddPropertyDiff("Tags")
 	} else {
@@ -370,6 +455,31 @@ func (c *Control) Diff(other *Control) *DashboardTreeItemDiffs {
 		}
 	}
 
+	if c.Remediation != nil {
+		if !c.Remediation.Equals(other.Remediation) {
+			res.AddPropertyDiff("Remediation")
+		}
+	} else if other.Remediation != nil {
+		res.AddPropertyDiff("Remediation")
+	}
+	if c.Evidence != nil {
+		if !c.Evidence.Equals(other.Evidence) {
+			res.AddPropertyDiff("Evidence")
+		}
+	} else if other.Evidence != nil {
+		res.AddPropertyDiff("Evidence")
+	}
+	if c.Precondition != nil {
+		if !c.Precondition.Equals(other.Precondition) {
+			res.AddPropertyDiff("Precondition")
+		}
+	} else if other.Precondition != nil {
+		res.AddPropertyDiff("Precondition")
+	}
+	if strings.Join(c.DependsOnNames(), ",") != strings.Join(other.DependsOnNames(), ",") {
+		res.AddPropertyDiff("DependsOn")
+	}
+
 	res.dashboardLeafNodeDiff(c, other)
 	res.queryProviderDiff(c, other)
 
@@ -401,6 +511,9 @@ func (c *Control) setBaseProperties(resourceMapProvider ModResourcesProvider) {
 	if c.Severity == nil {
 		c.Severity = c.Base.Severity
 	}
+	if c.Weight == nil {
+		c.Weight = c.Base.Weight
+	}
 	if c.SQL == nil {
 		c.SQL = c.Base.SQL
 	}
@@ -426,5 +539,23 @@ func (c *Control) setBaseProperties(resourceMapProvider ModResourcesProvider) {
 	if c.Display == nil {
 		c.Display = c.Base.Display
 	}
+	if c.Remediation == nil {
+		c.Remediation = c.Base.Remediation
+	} else if c.Base.Remediation != nil {
+		c.Remediation.Merge(c.Base.Remediation)
+	}
+	if c.Evidence == nil {
+		c.Evidence = c.Base.Evidence
+	} else if c.Base.Evidence != nil {
+		c.Evidence.Merge(c.Base.Evidence)
+	}
+	if c.DependsOn == nil {
+		c.DependsOn = c.Base.DependsOn
+	}
+	if c.Precondition == nil {
+		c.Precondition = c.Base.Precondition
+	} else if c.Base.Precondition != nil {
+		c.Precondition.Merge(c.Base.Precondition)
+	}
 	c.MergeRuntimeDependencies(c.Base)
 }
@@ -0,0 +1,73 @@
+package modconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// the states of a control while searching the dependency graph
+const (
+	dependencyUnvisited = iota
+	dependencyVisiting
+	dependencyVisited
+)
+
+// ValidateControlDependencies performs a depth-first search of the depends_on graph of all controls in the mod,
+// returning an error diagnostic for each dependency cycle found
+func (m *Mod) ValidateControlDependencies() hcl.Diagnostics {
+	controls := m.ResourceMaps.Controls
+	names := make([]string, 0, len(controls))
+	for name := range controls {
+		names = append(names, name)
+	}
+	// sort the names, so the reported cycles are deterministic
+	sort.Strings(names)
+
+	var diags hcl.Diagnostics
+	state := make(map[string]int)
+	var path []*Control
+	var visit func(control *Control)
+	visit = func(control *Control) {
+		name := control.Name()
+		switch state[name] {
+		case dependencyVisited:
+			return
+		case dependencyVisiting:
+			// the control is already on the path - the path from it to here is a cycle
+			var cycle []string
+			for i := len(path) - 1; i >= 0; i-- {
+				cycle = append([]string{path[i].Name()}, cycle...)
+				if path[i].Name() == name {
+					break
+				}
+			}
+			cycle = append(cycle, name)
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("control dependencies contain a cycle: %s", strings.Join(cycle, " -> ")),
+				Subject:  &control.DeclRange,
+			})
+			return
+		}
+
+		state[name] = dependencyVisiting
+		path = append(path, control)
+		for _, dep := range control.DependsOn {
+			// use the control from the resource map if there is one, as this will have its dependencies populated
+			if resolved, ok := controls[dep.Name()]; ok {
+				dep = resolved
+			}
+			visit(dep)
+		}
+		path = path[:len(path)-1]
+		state[name] = dependencyVisited
+	}
+
+	for _, name := range names {
+		visit(controls[name])
+	}
+	return diags
+}
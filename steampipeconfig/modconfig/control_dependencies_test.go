@@ -0,0 +1,62 @@
+package modconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+type controlDependenciesTest struct {
+	// map of control name to the names of the controls it depends on
+	dependencies map[string][]string
+	// the expected cycle, or empty if there is none
+	expected string
+}
+
+var testCasesValidateControlDependencies = map[string]controlDependenciesTest{
+	"no dependencies": {
+		dependencies: map[string][]string{"c1": nil, "c2": nil},
+	},
+	"chain": {
+		dependencies: map[string][]string{"c1": {"c2"}, "c2": {"c3"}, "c3": nil},
+	},
+	"diamond": {
+		dependencies: map[string][]string{"c1": {"c2", "c3"}, "c2": {"c4"}, "c3": {"c4"}, "c4": nil},
+	},
+	"two control cycle": {
+		dependencies: map[string][]string{"c1": {"c2"}, "c2": {"c1"}},
+		expected:     "m1.control.c1 -> m1.control.c2 -> m1.control.c1",
+	},
+	"indirect cycle": {
+		dependencies: map[string][]string{"c1": {"c2"}, "c2": {"c3"}, "c3": {"c4"}, "c4": {"c2"}},
+		expected:     "m1.control.c2 -> m1.control.c3 -> m1.control.c4 -> m1.control.c2",
+	},
+}
+
+func TestValidateControlDependencies(t *testing.T) {
+	for name, test := range testCasesValidateControlDependencies {
+		mod := NewMod("m1", "", hcl.Range{})
+		controls := make(map[string]*Control)
+		for controlName := range test.dependencies {
+			controls[controlName] = &Control{FullName: "m1.control." + controlName}
+			mod.ResourceMaps.Controls["m1.control."+controlName] = controls[controlName]
+		}
+		for controlName, deps := range test.dependencies {
+			for _, dep := range deps {
+				controls[controlName].DependsOn = append(controls[controlName].DependsOn, controls[dep])
+			}
+		}
+
+		diags := mod.ValidateControlDependencies()
+		if test.expected == "" {
+			if diags.HasErrors() {
+				t.Errorf("Test: '%s'' FAILED : unexpected error %s", name, diags.Error())
+			}
+			continue
+		}
+		if len(diags) != 1 || !strings.Contains(diags[0].Summary, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected a single cycle %s, got %v", name, test.expected, diags)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package modconfig
+
+import (
+	"fmt"
+	"strings"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/utils"
+)
+
+// ControlEvidence specifies additional data to capture for each control result
+type ControlEvidence struct {
+	// columns of the control query to capture as evidence, in addition to the dimensions
+	Columns []string `cty:"columns" hcl:"columns,optional" json:"columns,omitempty"`
+	// a companion query run after the control query - it must return a 'resource' column,
+	// which is used to match evidence rows to control results
+	SQL *string `cty:"sql" hcl:"sql" json:"sql,omitempty"`
+}
+
+func (e *ControlEvidence) Equals(other *ControlEvidence) bool {
+	if other == nil {
+		return false
+	}
+
+	return utils.SafeStringsEqual(e.SQL, other.SQL) &&
+		strings.Join(e.Columns, ",") == strings.Join(other.Columns, ",")
+}
+
+func (e *ControlEvidence) Merge(other *ControlEvidence) {
+	if e.Columns == nil {
+		e.Columns = other.Columns
+	}
+	if e.SQL == nil {
+		e.SQL = other.SQL
+	}
+}
+
+func (e *ControlEvidence) String() string {
+	return fmt.Sprintf("columns: %s, sql: %s", strings.Join(e.Columns, ","), typehelpers.SafeString(e.SQL))
+}
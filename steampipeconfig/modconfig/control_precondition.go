@@ -0,0 +1,30 @@
+package modconfig
+
+import "github.com/turbot/steampipe/utils"
+
+// ControlPrecondition is a query which must return true for a control to be run
+// if it returns false, the control is skipped
+type ControlPrecondition struct {
+	// a query returning a single boolean value
+	SQL *string `cty:"sql" hcl:"sql" json:"sql,omitempty"`
+	// the reason given when the control is skipped
+	Reason *string `cty:"reason" hcl:"reason" json:"reason,omitempty"`
+}
+
+func (p *ControlPrecondition) Equals(other *ControlPrecondition) bool {
+	if other == nil {
+		return false
+	}
+
+	return utils.SafeStringsEqual(p.SQL, other.SQL) &&
+		utils.SafeStringsEqual(p.Reason, other.Reason)
+}
+
+func (p *ControlPrecondition) Merge(other *ControlPrecondition) {
+	if p.SQL == nil {
+		p.SQL = other.SQL
+	}
+	if p.Reason == nil {
+		p.Reason = other.Reason
+	}
+}
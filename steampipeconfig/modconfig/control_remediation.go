@@ -0,0 +1,31 @@
+package modconfig
+
+import "github.com/turbot/steampipe/utils"
+
+// ControlRemediation describes how to fix the resources for which a control alarms
+type ControlRemediation struct {
+	// markdown guidance describing the fix
+	Guidance *string `cty:"guidance" hcl:"guidance" json:"guidance,omitempty"`
+	// a command or script fragment, templated on the result columns, which remediates a single resource
+	// the template values are shell-quoted, so each is passed to the command as a single literal argument
+	// e.g. "aws s3api put-bucket-versioning --bucket {{ .resource }} --versioning-configuration Status=Enabled"
+	Command *string `cty:"command" hcl:"command" json:"command,omitempty"`
+}
+
+func (r *ControlRemediation) Equals(other *ControlRemediation) bool {
+	if other == nil {
+		return false
+	}
+
+	return utils.SafeStringsEqual(r.Guidance, other.Guidance) &&
+		utils.SafeStringsEqual(r.Command, other.Command)
+}
+
+func (r *ControlRemediation) Merge(other *ControlRemediation) {
+	if r.Guidance == nil {
+		r.Guidance = other.Guidance
+	}
+	if r.Command == nil {
+		r.Command = other.Command
+	}
+}
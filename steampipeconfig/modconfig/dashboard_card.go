@@ -2,6 +2,7 @@ package modconfig
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/turbot/steampipe/constants"
 
@@ -30,6 +31,7 @@ type DashboardCard struct {
 	Icon    *string        `cty:"icon" hcl:"icon" column:"icon,text" json:"icon,omitempty"`
 	HREF    *string        `cty:"href" hcl:"href" json:"href,omitempty"`
 	Display *string        `cty:"display" hcl:"display" json:"display,omitempty"`
+	Timeout *string        `cty:"timeout" hcl:"timeout" json:"timeout,omitempty"`
 	OnHooks []*DashboardOn `cty:"on" hcl:"on,block" json:"on,omitempty"`
 
 	// QueryProvider
@@ -81,6 +83,9 @@ func (c *DashboardCard) Name() string {
 // OnDecoded implements HclResource
 func (c *DashboardCard) OnDecoded(block *hcl.Block, resourceMapProvider ModResourcesProvider) hcl.Diagnostics {
 	c.setBaseProperties(resourceMapProvider)
+	if diags := validateDashboardTimeout(c, c.Timeout); diags.HasErrors() {
+		return diags
+	}
 	return nil
 }
 
@@ -180,6 +185,10 @@ func (c *DashboardCard) Diff(other *DashboardCard) *DashboardTreeItemDiffs {
 		res.AddPropertyDiff("HREF")
 	}
 
+	if !utils.SafeStringsEqual(c.Timeout, other.Timeout) {
+		res.AddPropertyDiff("Timeout")
+	}
+
 	res.populateChildDiffs(c, other)
 	res.queryProviderDiff(c, other)
 	res.dashboardLeafNodeDiff(c, other)
@@ -200,6 +209,11 @@ func (c *DashboardCard) GetDisplay() *string {
 	return c.Display
 }
 
+// GetTimeout implements DashboardLeafNodeWithTimeout
+func (c *DashboardCard) GetTimeout() time.Duration {
+	return parseDashboardTimeout(c.Timeout)
+}
+
 // GetUnqualifiedName implements DashboardLeafNode
 func (c *DashboardCard) GetUnqualifiedName() string {
 	return c.UnqualifiedName
@@ -286,6 +300,10 @@ func (c *DashboardCard) setBaseProperties(resourceMapProvider ModResourcesProvid
 		c.Display = c.Base.Display
 	}
 
+	if c.Timeout == nil {
+		c.Timeout = c.Base.Timeout
+	}
+
 	if c.Icon == nil {
 		c.Icon = c.Base.Icon
 	}
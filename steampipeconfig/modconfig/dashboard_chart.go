@@ -2,6 +2,7 @@ package modconfig
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/turbot/steampipe/constants"
 
@@ -35,6 +36,7 @@ type DashboardChart struct {
 	Transform  *string                          `cty:"transform" hcl:"transform" json:"transform,omitempty"`
 	Series     map[string]*DashboardChartSeries `cty:"series" json:"series,omitempty"`
 	Display    *string                          `cty:"display" hcl:"display" json:"display,omitempty"`
+	Timeout    *string                          `cty:"timeout" hcl:"timeout" json:"timeout,omitempty"`
 	OnHooks    []*DashboardOn                   `cty:"on" hcl:"on,block" json:"on,omitempty"`
 
 	// QueryProvider
@@ -85,6 +87,9 @@ func (c *DashboardChart) Name() string {
 // OnDecoded implements HclResource
 func (c *DashboardChart) OnDecoded(block *hcl.Block, resourceMapProvider ModResourcesProvider) hcl.Diagnostics {
 	c.setBaseProperties(resourceMapProvider)
+	if diags := validateDashboardTimeout(c, c.Timeout); diags.HasErrors() {
+		return diags
+	}
 	// populate series map
 	if len(c.SeriesList) > 0 {
 		c.Series = make(map[string]*DashboardChartSeries, len(c.SeriesList))
@@ -210,6 +215,10 @@ func (c *DashboardChart) Diff(other *DashboardChart) *DashboardTreeItemDiffs {
 		res.AddPropertyDiff("Axes")
 	}
 
+	if !utils.SafeStringsEqual(c.Timeout, other.Timeout) {
+		res.AddPropertyDiff("Timeout")
+	}
+
 	res.populateChildDiffs(c, other)
 	res.queryProviderDiff(c, other)
 	res.dashboardLeafNodeDiff(c, other)
@@ -230,6 +239,11 @@ func (c *DashboardChart) GetDisplay() *string {
 	return c.Display
 }
 
+// GetTimeout implements DashboardLeafNodeWithTimeout
+func (c *DashboardChart) GetTimeout() time.Duration {
+	return parseDashboardTimeout(c.Timeout)
+}
+
 // GetUnqualifiedName implements DashboardLeafNode, ModTreeItem
 func (c *DashboardChart) GetUnqualifiedName() string {
 	return c.UnqualifiedName
@@ -302,6 +316,10 @@ func (c *DashboardChart) setBaseProperties(resourceMapProvider ModResourcesProvi
 		c.Display = c.Base.Display
 	}
 
+	if c.Timeout == nil {
+		c.Timeout = c.Base.Timeout
+	}
+
 	if c.Axes == nil {
 		c.Axes = c.Base.Axes
 	} else {
@@ -2,6 +2,7 @@ package modconfig
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/turbot/steampipe/constants"
 
@@ -30,6 +31,7 @@ type DashboardHierarchy struct {
 	CategoryList DashboardHierarchyCategoryList         `cty:"category_list" hcl:"category,block" column:"category,jsonb" json:"-"`
 	Categories   map[string]*DashboardHierarchyCategory `cty:"categories" json:"categories"`
 	Display      *string                                `cty:"display" hcl:"display" json:"display,omitempty"`
+	Timeout      *string                                `cty:"timeout" hcl:"timeout" json:"timeout,omitempty"`
 	OnHooks      []*DashboardOn                         `cty:"on" hcl:"on,block" json:"on,omitempty"`
 
 	// QueryProvider
@@ -79,6 +81,9 @@ func (h *DashboardHierarchy) Name() string {
 // OnDecoded implements HclResource
 func (h *DashboardHierarchy) OnDecoded(block *hcl.Block, resourceMapProvider ModResourcesProvider) hcl.Diagnostics {
 	h.setBaseProperties(resourceMapProvider)
+	if diags := validateDashboardTimeout(h, h.Timeout); diags.HasErrors() {
+		return diags
+	}
 	// populate categories map
 	if len(h.CategoryList) > 0 {
 		h.Categories = make(map[string]*DashboardHierarchyCategory, len(h.CategoryList))
@@ -179,6 +184,10 @@ func (h *DashboardHierarchy) Diff(other *DashboardHierarchy) *DashboardTreeItemD
 		}
 	}
 
+	if !utils.SafeStringsEqual(h.Timeout, other.Timeout) {
+		res.AddPropertyDiff("Timeout")
+	}
+
 	res.populateChildDiffs(h, other)
 	res.queryProviderDiff(h, other)
 	res.dashboardLeafNodeDiff(h, other)
@@ -199,6 +208,11 @@ func (h *DashboardHierarchy) GetDisplay() *string {
 	return h.Display
 }
 
+// GetTimeout implements DashboardLeafNodeWithTimeout
+func (h *DashboardHierarchy) GetTimeout() time.Duration {
+	return parseDashboardTimeout(h.Timeout)
+}
+
 // GetUnqualifiedName implements DashboardLeafNode, ModTreeItem
 func (h *DashboardHierarchy) GetUnqualifiedName() string {
 	return h.UnqualifiedName
@@ -271,6 +285,10 @@ func (h *DashboardHierarchy) setBaseProperties(resourceMapProvider ModResourcesP
 		h.Display = h.Base.Display
 	}
 
+	if h.Timeout == nil {
+		h.Timeout = h.Base.Timeout
+	}
+
 	if h.Width == nil {
 		h.Width = h.Base.Width
 	}
@@ -29,6 +29,8 @@ type DashboardInput struct {
 	Display     *string                 `cty:"display" hcl:"display" json:"display,omitempty"`
 	OnHooks     []*DashboardOn          `cty:"on" hcl:"on,block" json:"on,omitempty"`
 	Options     []*DashboardInputOption `cty:"options" hcl:"option,block" json:"options,omitempty"`
+	Min         *string                 `cty:"min" hcl:"min" json:"min,omitempty"`
+	Max         *string                 `cty:"max" hcl:"max" json:"max,omitempty"`
 
 	// QueryProvider
 	SQL                   *string     `cty:"sql" hcl:"sql" column:"sql,text" json:"-"`
@@ -62,6 +64,8 @@ func (i *DashboardInput) Clone() *DashboardInput {
 		Display:                  i.Display,
 		OnHooks:                  i.OnHooks,
 		Options:                  i.Options,
+		Min:                      i.Min,
+		Max:                      i.Max,
 		SQL:                      i.SQL,
 		Query:                    i.Query,
 		PreparedStatementName:    i.PreparedStatementName,
@@ -107,6 +111,14 @@ func (i *DashboardInput) Name() string {
 // OnDecoded implements HclResource
 func (i *DashboardInput) OnDecoded(block *hcl.Block, resourceMapProvider ModResourcesProvider) hcl.Diagnostics {
 	i.setBaseProperties(resourceMapProvider)
+	if err := i.validateBounds(); err != nil {
+		return hcl.Diagnostics{&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s has invalid bounds", i.Name()),
+			Detail:   err.Error(),
+			Subject:  &i.DeclRange,
+		}}
+	}
 	return nil
 }
 
@@ -198,6 +210,14 @@ func (i *DashboardInput) Diff(other *DashboardInput) *DashboardTreeItemDiffs {
 		res.AddPropertyDiff("Placeholder")
 	}
 
+	if !utils.SafeStringsEqual(i.Min, other.Min) {
+		res.AddPropertyDiff("Min")
+	}
+
+	if !utils.SafeStringsEqual(i.Max, other.Max) {
+		res.AddPropertyDiff("Max")
+	}
+
 	if len(i.Options) != len(other.Options) {
 		res.AddPropertyDiff("Options")
 	} else {
@@ -334,6 +354,14 @@ func (i *DashboardInput) setBaseProperties(resourceMapProvider ModResourcesProvi
 		i.Width = i.Base.Width
 	}
 
+	if i.Min == nil {
+		i.Min = i.Base.Min
+	}
+
+	if i.Max == nil {
+		i.Max = i.Base.Max
+	}
+
 	if i.SQL == nil {
 		i.SQL = i.Base.SQL
 	}
@@ -0,0 +1,282 @@
+package modconfig
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	typehelpers "github.com/turbot/go-kit/types"
+)
+
+// the supported dashboard input types
+const (
+	DashboardInputTypeSelect      = "select"
+	DashboardInputTypeMultiSelect = "multiselect"
+	DashboardInputTypeCombo       = "combo"
+	DashboardInputTypeMultiCombo  = "multicombo"
+	DashboardInputTypeText        = "text"
+	DashboardInputTypeNumber      = "number"
+	DashboardInputTypeDate        = "date"
+	DashboardInputTypeDateRange   = "date_range"
+)
+
+// the format of date input values and bounds
+const DashboardInputDateFormat = "2006-01-02"
+
+// ValidateValue checks the given value is valid for the type, options and bounds of the input
+//
+// the expected values are:
+//
+//	select, combo, text:    a string
+//	multiselect, multicombo: an array of strings - min and max bound the number of selected options
+//	number:                 a number, or a string containing a number - min and max bound the value
+//	date:                   a date string (YYYY-MM-DD) - min and max bound the date
+//	date_range:             an array of 2 date strings, [from, to] - min and max bound both dates
+//
+// a nil value is always valid - it means the input has no value
+func (i *DashboardInput) ValidateValue(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch typehelpers.SafeString(i.Type) {
+	case DashboardInputTypeSelect:
+		s, err := inputStringValue(value)
+		if err != nil {
+			return err
+		}
+		return i.validateOption(s)
+	case DashboardInputTypeCombo, DashboardInputTypeText:
+		_, err := inputStringValue(value)
+		return err
+	case DashboardInputTypeMultiSelect, DashboardInputTypeMultiCombo:
+		return i.validateMultiValue(value)
+	case DashboardInputTypeNumber:
+		return i.validateNumber(value)
+	case DashboardInputTypeDate:
+		s, err := inputStringValue(value)
+		if err != nil {
+			return err
+		}
+		_, err = i.validateDate(s)
+		return err
+	case DashboardInputTypeDateRange:
+		return i.validateDateRange(value)
+	}
+	// no validation for inputs with no type, or types unknown to the server
+	return nil
+}
+
+func (i *DashboardInput) validateMultiValue(value interface{}) error {
+	values, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("expected an array of values")
+	}
+	for _, v := range values {
+		s, err := inputStringValue(v)
+		if err != nil {
+			return err
+		}
+		// combo inputs allow values which are not options
+		if typehelpers.SafeString(i.Type) == DashboardInputTypeMultiSelect {
+			if err := i.validateOption(s); err != nil {
+				return err
+			}
+		}
+	}
+
+	min, max, err := i.countBounds()
+	if err != nil {
+		return err
+	}
+	if min != nil && len(values) < *min {
+		return fmt.Errorf("at least %d values must be selected", *min)
+	}
+	if max != nil && len(values) > *max {
+		return fmt.Errorf("at most %d values may be selected", *max)
+	}
+	return nil
+}
+
+// validateOption checks the value is one of the options of the input
+// inputs whose options are populated by a query cannot be validated
+func (i *DashboardInput) validateOption(value string) error {
+	if len(i.Options) == 0 {
+		return nil
+	}
+	for _, o := range i.Options {
+		if o.Name == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' is not a valid option", value)
+}
+
+func (i *DashboardInput) validateNumber(value interface{}) error {
+	var n float64
+	switch v := value.(type) {
+	case float64:
+		n = v
+	case int:
+		n = float64(v)
+	case string:
+		var err error
+		if n, err = strconv.ParseFloat(v, 64); err != nil {
+			return fmt.Errorf("'%s' is not a number", v)
+		}
+	default:
+		return fmt.Errorf("expected a number")
+	}
+
+	min, max, err := i.numberBounds()
+	if err != nil {
+		return err
+	}
+	if min != nil && n < *min {
+		return fmt.Errorf("%v is less than the minimum of %v", n, *min)
+	}
+	if max != nil && n > *max {
+		return fmt.Errorf("%v is greater than the maximum of %v", n, *max)
+	}
+	return nil
+}
+
+func (i *DashboardInput) validateDateRange(value interface{}) error {
+	values, ok := value.([]interface{})
+	if !ok || len(values) != 2 {
+		return fmt.Errorf("expected an array of 2 dates")
+	}
+	var dates [2]time.Time
+	for idx, v := range values {
+		s, err := inputStringValue(v)
+		if err != nil {
+			return err
+		}
+		if dates[idx], err = i.validateDate(s); err != nil {
+			return err
+		}
+	}
+	if dates[1].Before(dates[0]) {
+		return fmt.Errorf("the end of the date range is before the start")
+	}
+	return nil
+}
+
+// validateDate parses the date and checks it is within the bounds of the input
+func (i *DashboardInput) validateDate(value string) (time.Time, error) {
+	date, err := time.Parse(DashboardInputDateFormat, value)
+	if err != nil {
+		return date, fmt.Errorf("'%s' is not a date in the format YYYY-MM-DD", value)
+	}
+
+	min, max, err := i.dateBounds()
+	if err != nil {
+		return date, err
+	}
+	if min != nil && date.Before(*min) {
+		return date, fmt.Errorf("%s is before the minimum of %s", value, *i.Min)
+	}
+	if max != nil && date.After(*max) {
+		return date, fmt.Errorf("%s is after the maximum of %s", value, *i.Max)
+	}
+	return date, nil
+}
+
+// validateBounds checks that the min and max of the input can be parsed for its type
+func (i *DashboardInput) validateBounds() error {
+	if i.Min == nil && i.Max == nil {
+		return nil
+	}
+	var err error
+	switch typehelpers.SafeString(i.Type) {
+	case DashboardInputTypeMultiSelect, DashboardInputTypeMultiCombo:
+		_, _, err = i.countBounds()
+	case DashboardInputTypeNumber:
+		_, _, err = i.numberBounds()
+	case DashboardInputTypeDate, DashboardInputTypeDateRange:
+		_, _, err = i.dateBounds()
+	default:
+		err = fmt.Errorf("min and max are only supported for %s, %s, %s, %s and %s inputs",
+			DashboardInputTypeMultiSelect, DashboardInputTypeMultiCombo, DashboardInputTypeNumber, DashboardInputTypeDate, DashboardInputTypeDateRange)
+	}
+	return err
+}
+
+func (i *DashboardInput) countBounds() (min, max *int, err error) {
+	parse := func(bound *string) (*int, error) {
+		if bound == nil {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(*bound)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("'%s' is not a valid number of selected values", *bound)
+		}
+		return &n, nil
+	}
+	if min, err = parse(i.Min); err != nil {
+		return
+	}
+	max, err = parse(i.Max)
+	return
+}
+
+func (i *DashboardInput) numberBounds() (min, max *float64, err error) {
+	parse := func(bound *string) (*float64, error) {
+		if bound == nil {
+			return nil, nil
+		}
+		n, err := strconv.ParseFloat(*bound, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a number", *bound)
+		}
+		return &n, nil
+	}
+	if min, err = parse(i.Min); err != nil {
+		return
+	}
+	max, err = parse(i.Max)
+	return
+}
+
+func (i *DashboardInput) dateBounds() (min, max *time.Time, err error) {
+	parse := func(bound *string) (*time.Time, error) {
+		if bound == nil {
+			return nil, nil
+		}
+		date, err := time.Parse(DashboardInputDateFormat, *bound)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a date in the format YYYY-MM-DD", *bound)
+		}
+		return &date, nil
+	}
+	if min, err = parse(i.Min); err != nil {
+		return
+	}
+	max, err = parse(i.Max)
+	return
+}
+
+func inputStringValue(value interface{}) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string value, got %v", value)
+	}
+	return s, nil
+}
+
+// ValidateInputValues validates the given input values against the inputs of the dashboard
+// it returns a map of the validation errors, keyed by input name
+// values for inputs which do not exist in the dashboard are ignored
+func (d *Dashboard) ValidateInputValues(inputValues map[string]interface{}) map[string]error {
+	validationErrors := make(map[string]error)
+	for name, value := range inputValues {
+		input, ok := d.GetInput(name)
+		if !ok {
+			continue
+		}
+		if err := input.ValidateValue(value); err != nil {
+			validationErrors[name] = err
+		}
+	}
+	return validationErrors
+}
@@ -0,0 +1,185 @@
+package modconfig
+
+import (
+	"testing"
+)
+
+type inputValidationTest struct {
+	input *DashboardInput
+	value interface{}
+	valid bool
+}
+
+func newTestInput(inputType string, min, max *string, options ...string) *DashboardInput {
+	input := &DashboardInput{Type: &inputType, Min: min, Max: max}
+	for _, o := range options {
+		input.Options = append(input.Options, &DashboardInputOption{Name: o})
+	}
+	return input
+}
+
+func bound(s string) *string {
+	return &s
+}
+
+var testCasesInputValidation = map[string]inputValidationTest{
+	"nil value": {
+		input: newTestInput(DashboardInputTypeNumber, nil, nil),
+		value: nil,
+		valid: true,
+	},
+	"untyped input": {
+		input: &DashboardInput{},
+		value: 123.0,
+		valid: true,
+	},
+	"select option": {
+		input: newTestInput(DashboardInputTypeSelect, nil, nil, "us-east-1", "us-east-2"),
+		value: "us-east-1",
+		valid: true,
+	},
+	"select not an option": {
+		input: newTestInput(DashboardInputTypeSelect, nil, nil, "us-east-1", "us-east-2"),
+		value: "eu-west-1",
+		valid: false,
+	},
+	"select query options": {
+		input: newTestInput(DashboardInputTypeSelect, nil, nil),
+		value: "eu-west-1",
+		valid: true,
+	},
+	"select not a string": {
+		input: newTestInput(DashboardInputTypeSelect, nil, nil),
+		value: 1.0,
+		valid: false,
+	},
+	"combo not an option": {
+		input: newTestInput(DashboardInputTypeCombo, nil, nil, "us-east-1"),
+		value: "eu-west-1",
+		valid: true,
+	},
+	"multiselect options": {
+		input: newTestInput(DashboardInputTypeMultiSelect, nil, nil, "a", "b", "c"),
+		value: []interface{}{"a", "c"},
+		valid: true,
+	},
+	"multiselect not an option": {
+		input: newTestInput(DashboardInputTypeMultiSelect, nil, nil, "a", "b", "c"),
+		value: []interface{}{"a", "d"},
+		valid: false,
+	},
+	"multiselect not an array": {
+		input: newTestInput(DashboardInputTypeMultiSelect, nil, nil),
+		value: "a",
+		valid: false,
+	},
+	"multiselect too few": {
+		input: newTestInput(DashboardInputTypeMultiSelect, bound("2"), bound("3"), "a", "b", "c", "d"),
+		value: []interface{}{"a"},
+		valid: false,
+	},
+	"multiselect too many": {
+		input: newTestInput(DashboardInputTypeMultiSelect, bound("2"), bound("3"), "a", "b", "c", "d"),
+		value: []interface{}{"a", "b", "c", "d"},
+		valid: false,
+	},
+	"multicombo within bounds": {
+		input: newTestInput(DashboardInputTypeMultiCombo, nil, bound("2")),
+		value: []interface{}{"x", "y"},
+		valid: true,
+	},
+	"number": {
+		input: newTestInput(DashboardInputTypeNumber, bound("1"), bound("10")),
+		value: 5.0,
+		valid: true,
+	},
+	"number string": {
+		input: newTestInput(DashboardInputTypeNumber, bound("1"), bound("10")),
+		value: "2.5",
+		valid: true,
+	},
+	"number below min": {
+		input: newTestInput(DashboardInputTypeNumber, bound("1"), bound("10")),
+		value: 0.5,
+		valid: false,
+	},
+	"number above max": {
+		input: newTestInput(DashboardInputTypeNumber, bound("1"), bound("10")),
+		value: "11",
+		valid: false,
+	},
+	"number not a number": {
+		input: newTestInput(DashboardInputTypeNumber, nil, nil),
+		value: "ten",
+		valid: false,
+	},
+	"date": {
+		input: newTestInput(DashboardInputTypeDate, bound("2022-01-01"), nil),
+		value: "2022-06-01",
+		valid: true,
+	},
+	"date before min": {
+		input: newTestInput(DashboardInputTypeDate, bound("2022-01-01"), nil),
+		value: "2021-12-31",
+		valid: false,
+	},
+	"date invalid format": {
+		input: newTestInput(DashboardInputTypeDate, nil, nil),
+		value: "01/06/2022",
+		valid: false,
+	},
+	"date range": {
+		input: newTestInput(DashboardInputTypeDateRange, nil, bound("2022-12-31")),
+		value: []interface{}{"2022-06-01", "2022-06-30"},
+		valid: true,
+	},
+	"date range inverted": {
+		input: newTestInput(DashboardInputTypeDateRange, nil, nil),
+		value: []interface{}{"2022-06-30", "2022-06-01"},
+		valid: false,
+	},
+	"date range after max": {
+		input: newTestInput(DashboardInputTypeDateRange, nil, bound("2022-12-31")),
+		value: []interface{}{"2022-12-01", "2023-01-31"},
+		valid: false,
+	},
+	"date range single date": {
+		input: newTestInput(DashboardInputTypeDateRange, nil, nil),
+		value: []interface{}{"2022-06-01"},
+		valid: false,
+	},
+}
+
+func TestDashboardInputValidateValue(t *testing.T) {
+	for name, test := range testCasesInputValidation {
+		err := test.input.ValidateValue(test.value)
+		if test.valid && err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected a validation error", name)
+		}
+	}
+}
+
+var testCasesInputBounds = map[string]inputValidationTest{
+	"number bounds":             {input: newTestInput(DashboardInputTypeNumber, bound("-1.5"), bound("10")), valid: true},
+	"invalid number bound":      {input: newTestInput(DashboardInputTypeNumber, bound("one"), nil), valid: false},
+	"date bounds":               {input: newTestInput(DashboardInputTypeDateRange, bound("2022-01-01"), bound("2022-12-31")), valid: true},
+	"invalid date bound":        {input: newTestInput(DashboardInputTypeDate, nil, bound("2022-13-01")), valid: false},
+	"negative selection bound":  {input: newTestInput(DashboardInputTypeMultiSelect, bound("-1"), nil), valid: false},
+	"bounds on a select input":  {input: newTestInput(DashboardInputTypeSelect, bound("1"), nil), valid: false},
+	"no bounds on a text input": {input: newTestInput(DashboardInputTypeText, nil, nil), valid: true},
+}
+
+func TestDashboardInputValidateBounds(t *testing.T) {
+	for name, test := range testCasesInputBounds {
+		err := test.input.validateBounds()
+		if test.valid && err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected a validation error", name)
+		}
+	}
+}
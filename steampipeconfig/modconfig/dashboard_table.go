@@ -2,6 +2,7 @@ package modconfig
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/turbot/steampipe/constants"
 
@@ -30,6 +31,7 @@ type DashboardTable struct {
 	ColumnList DashboardTableColumnList         `cty:"column_list" hcl:"column,block" column:"columns,jsonb" json:"-"`
 	Columns    map[string]*DashboardTableColumn `cty:"columns" json:"columns,omitempty"`
 	Display    *string                          `cty:"display" hcl:"display" json:"display,omitempty"`
+	Timeout    *string                          `cty:"timeout" hcl:"timeout" json:"timeout,omitempty"`
 	OnHooks    []*DashboardOn                   `cty:"on" hcl:"on,block" json:"on,omitempty"`
 
 	// QueryProvider
@@ -79,6 +81,9 @@ func (t *DashboardTable) Name() string {
 // OnDecoded implements HclResource
 func (t *DashboardTable) OnDecoded(_ *hcl.Block, resourceMapProvider ModResourcesProvider) hcl.Diagnostics {
 	t.setBaseProperties(resourceMapProvider)
+	if diags := validateDashboardTimeout(t, t.Timeout); diags.HasErrors() {
+		return diags
+	}
 	// populate columns map
 	if len(t.ColumnList) > 0 {
 		t.Columns = make(map[string]*DashboardTableColumn, len(t.ColumnList))
@@ -179,6 +184,10 @@ func (t *DashboardTable) Diff(other *DashboardTable) *DashboardTreeItemDiffs {
 		}
 	}
 
+	if !utils.SafeStringsEqual(t.Timeout, other.Timeout) {
+		res.AddPropertyDiff("Timeout")
+	}
+
 	res.populateChildDiffs(t, other)
 	res.queryProviderDiff(t, other)
 	res.dashboardLeafNodeDiff(t, other)
@@ -199,6 +208,11 @@ func (t *DashboardTable) GetDisplay() *string {
 	return t.Display
 }
 
+// GetTimeout implements DashboardLeafNodeWithTimeout
+func (t *DashboardTable) GetTimeout() time.Duration {
+	return parseDashboardTimeout(t.Timeout)
+}
+
 // GetUnqualifiedName implements DashboardLeafNode, ModTreeItem
 func (t *DashboardTable) GetUnqualifiedName() string {
 	return t.UnqualifiedName
@@ -279,6 +293,10 @@ func (t *DashboardTable) setBaseProperties(resourceMapProvider ModResourcesProvi
 		t.Display = t.Base.Display
 	}
 
+	if t.Timeout == nil {
+		t.Timeout = t.Base.Timeout
+	}
+
 	if t.ColumnList == nil {
 		t.ColumnList = t.Base.ColumnList
 	} else {
@@ -0,0 +1,30 @@
+package modconfig
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	typehelpers "github.com/turbot/go-kit/types"
+)
+
+// parseDashboardTimeout parses a dashboard node timeout, e.g. "30s"
+// it returns zero if the timeout is not set or is invalid
+func parseDashboardTimeout(timeout *string) time.Duration {
+	if d, err := time.ParseDuration(typehelpers.SafeString(timeout)); err == nil && d > 0 {
+		return d
+	}
+	return 0
+}
+
+func validateDashboardTimeout(resource HclResource, timeout *string) hcl.Diagnostics {
+	if timeout == nil || parseDashboardTimeout(timeout) > 0 {
+		return nil
+	}
+	return hcl.Diagnostics{&hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("%s has invalid timeout '%s'", resource.Name(), *timeout),
+		Detail:   "timeout must be a positive duration, e.g. \"30s\" or \"5m\"",
+		Subject:  resource.GetDeclRange(),
+	}}
+}
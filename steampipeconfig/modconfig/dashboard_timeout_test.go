@@ -0,0 +1,25 @@
+package modconfig
+
+import (
+	"testing"
+	"time"
+)
+
+var testCasesParseDashboardTimeout = map[string]struct {
+	timeout  *string
+	expected time.Duration
+}{
+	"not set":  {timeout: nil, expected: 0},
+	"seconds":  {timeout: bound("30s"), expected: 30 * time.Second},
+	"minutes":  {timeout: bound("5m"), expected: 5 * time.Minute},
+	"invalid":  {timeout: bound("30"), expected: 0},
+	"negative": {timeout: bound("-1m"), expected: 0},
+}
+
+func TestParseDashboardTimeout(t *testing.T) {
+	for name, test := range testCasesParseDashboardTimeout {
+		if timeout := parseDashboardTimeout(test.timeout); timeout != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", name, test.expected, timeout)
+		}
+	}
+}
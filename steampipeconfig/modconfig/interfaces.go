@@ -1,6 +1,8 @@
 package modconfig
 
 import (
+	"time"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -83,6 +85,13 @@ type DashboardLeafNode interface {
 	GetMetadata() *ResourceMetadata
 }
 
+// DashboardLeafNodeWithTimeout must be implemented by dashboard leaf nodes which support a query timeout
+type DashboardLeafNodeWithTimeout interface {
+	DashboardLeafNode
+	// GetTimeout returns the query timeout of the node, or zero if no timeout is set
+	GetTimeout() time.Duration
+}
+
 type ModResourcesProvider interface {
 	GetResourceMaps() *ModResources
 }
@@ -0,0 +1,83 @@
+package modconfig
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/turbot/go-kit/helpers"
+	typehelpers "github.com/turbot/go-kit/types"
+)
+
+const (
+	NotifierTypeWebhook = "webhook"
+	NotifierTypeSlack   = "slack"
+	NotifierTypeCommand = "command"
+
+	// NotifierEventComplete is raised whenever a check run completes
+	NotifierEventComplete = "complete"
+	// NotifierEventAlarm is raised when a check run completes with alarms or errors for controls or resources
+	// which did not alarm in the previous run of the same check
+	NotifierEventAlarm = "alarm"
+)
+
+// Notifier is a struct representing a check notifier, defined in the steampipe config
+//
+//	notifier "ops_channel" {
+//	  type = "slack"
+//	  url  = "https://hooks.slack.com/services/..."
+//	  on   = ["alarm"]
+//	}
+type Notifier struct {
+	Name string `json:"name"`
+	// supported values: "webhook", "slack", "command"
+	Type string `hcl:"type" json:"type"`
+	// the url to post to (webhook and slack notifiers)
+	Url *string `hcl:"url" json:"url,omitempty"`
+	// additional http headers (webhook notifiers)
+	Headers map[string]string `hcl:"headers,optional" json:"headers,omitempty"`
+	// the command to execute, and its args (command notifiers)
+	Command *string  `hcl:"command" json:"command,omitempty"`
+	Args    []string `hcl:"args,optional" json:"args,omitempty"`
+	// the events which trigger the notification - defaults to ["complete"]
+	On []string `hcl:"on,optional" json:"on,omitempty"`
+
+	DeclRange Range `json:"decl_range"`
+}
+
+func NewNotifier(block *hcl.Block) *Notifier {
+	return &Notifier{
+		Name:      block.Labels[0],
+		DeclRange: NewRange(block.TypeRange),
+	}
+}
+
+// ShouldNotify returns whether the notifier is configured to fire for the given event
+func (n *Notifier) ShouldNotify(event string) bool {
+	if len(n.On) == 0 {
+		return event == NotifierEventComplete
+	}
+	return helpers.StringSliceContains(n.On, event)
+}
+
+// Validate checks the notifier has the properties required by its type
+func (n *Notifier) Validate() []string {
+	var validationErrors []string
+	switch n.Type {
+	case NotifierTypeWebhook, NotifierTypeSlack:
+		if typehelpers.SafeString(n.Url) == "" {
+			validationErrors = append(validationErrors, fmt.Sprintf("notifier '%s' of type '%s' must specify 'url'", n.Name, n.Type))
+		}
+	case NotifierTypeCommand:
+		if typehelpers.SafeString(n.Command) == "" {
+			validationErrors = append(validationErrors, fmt.Sprintf("notifier '%s' of type '%s' must specify 'command'", n.Name, n.Type))
+		}
+	default:
+		validationErrors = append(validationErrors, fmt.Sprintf("notifier '%s' has invalid type '%s' - supported types are '%s', '%s' and '%s'", n.Name, n.Type, NotifierTypeWebhook, NotifierTypeSlack, NotifierTypeCommand))
+	}
+	for _, event := range n.On {
+		if event != NotifierEventComplete && event != NotifierEventAlarm {
+			validationErrors = append(validationErrors, fmt.Sprintf("notifier '%s' has invalid event '%s' - supported events are '%s' and '%s'", n.Name, event, NotifierEventComplete, NotifierEventAlarm))
+		}
+	}
+	return validationErrors
+}
@@ -0,0 +1,139 @@
+package modconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/utils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Schedule is a struct representing a scheduled run of dashboards and benchmarks, defined in the steampipe config
+// schedules are run by the dashboard server when it is started as part of the service
+//
+//	schedule "nightly_cis" {
+//	  cron       = "0 2 * * *"
+//	  targets    = ["aws_compliance.benchmark.cis_v140", "aws_insights.dashboard.aws_iam_dashboard"]
+//	  output_dir = "/var/steampipe/reports"
+//	  retention  = 30
+//	}
+type Schedule struct {
+	Name string `json:"name"`
+	// standard 5 field cron expression, or a macro such as @daily
+	Cron string `hcl:"cron" json:"cron"`
+	// the full names of the dashboards and benchmarks to run
+	Targets []string `hcl:"targets" json:"targets"`
+	// input values for dashboard targets, keyed by input name, e.g. "input.region"
+	// values may be of any type, e.g. a list of strings for a multiselect input
+	Inputs cty.Value `hcl:"inputs,optional" json:"-"`
+	// the input values converted to their json representation, as received by the REST API - populated by Validate
+	InputValues map[string]interface{} `json:"inputs,omitempty"`
+	// the check export formats to write for benchmark targets - defaults to ["json"]
+	// dashboard targets are always written as a json snapshot
+	Export []string `hcl:"export,optional" json:"export,omitempty"`
+	// the directory to write results to - defaults to a directory in the steampipe install dir
+	OutputDir *string `hcl:"output_dir" json:"output_dir,omitempty"`
+	// the number of runs of each target to retain - defaults to 10
+	Retention *int `hcl:"retention" json:"retention,omitempty"`
+	// the maximum duration of the run of each target, e.g. "30m" - defaults to 1 hour
+	Timeout *string `hcl:"timeout" json:"timeout,omitempty"`
+
+	DeclRange Range `json:"decl_range"`
+
+	cronSchedule *utils.CronSchedule
+}
+
+const (
+	ScheduleDefaultRetention = 10
+	ScheduleDefaultTimeout   = time.Hour
+)
+
+func NewSchedule(block *hcl.Block) *Schedule {
+	return &Schedule{
+		Name:      block.Labels[0],
+		DeclRange: NewRange(block.TypeRange),
+	}
+}
+
+// Validate checks the cron expression and targets of the schedule
+func (s *Schedule) Validate() []string {
+	var validationErrors []string
+	var err error
+	if s.cronSchedule, err = utils.ParseCronSchedule(s.Cron); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("schedule '%s': %s", s.Name, err.Error()))
+	}
+	if len(s.Targets) == 0 {
+		validationErrors = append(validationErrors, fmt.Sprintf("schedule '%s' must specify at least one target", s.Name))
+	}
+	for _, target := range s.Targets {
+		parsedName, err := ParseResourceName(target)
+		if err != nil || parsedName.Mod == "" || (parsedName.ItemType != BlockTypeDashboard && parsedName.ItemType != BlockTypeBenchmark) {
+			validationErrors = append(validationErrors, fmt.Sprintf("schedule '%s' has invalid target '%s' - targets must be the full name of a dashboard or benchmark, e.g. 'mod.benchmark.name'", s.Name, target))
+		}
+	}
+	if s.InputValues, err = scheduleInputValues(s.Inputs); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("schedule '%s' has invalid inputs: %s", s.Name, err.Error()))
+	}
+	if s.Retention != nil && *s.Retention < 1 {
+		validationErrors = append(validationErrors, fmt.Sprintf("schedule '%s' must have a retention of at least 1", s.Name))
+	}
+	if s.Timeout != nil {
+		if timeout, err := time.ParseDuration(*s.Timeout); err != nil || timeout <= 0 {
+			validationErrors = append(validationErrors, fmt.Sprintf("schedule '%s' has invalid timeout '%s'", s.Name, *s.Timeout))
+		}
+	}
+	return validationErrors
+}
+
+// NextRun returns the time of the first run of the schedule after t
+// NOTE: the schedule must have been validated
+func (s *Schedule) NextRun(t time.Time) time.Time {
+	return s.cronSchedule.Next(t)
+}
+
+// GetRetention returns the number of runs of each target to retain
+func (s *Schedule) GetRetention() int {
+	if s.Retention == nil {
+		return ScheduleDefaultRetention
+	}
+	return *s.Retention
+}
+
+// GetTimeout returns the maximum duration of the run of each target
+func (s *Schedule) GetTimeout() time.Duration {
+	if timeout, err := time.ParseDuration(typehelpers.SafeString(s.Timeout)); err == nil && timeout > 0 {
+		return timeout
+	}
+	return ScheduleDefaultTimeout
+}
+
+// GetExportFormats returns the check export formats for benchmark targets
+func (s *Schedule) GetExportFormats() []string {
+	if len(s.Export) == 0 {
+		return []string{"json"}
+	}
+	return s.Export
+}
+
+// convert the inputs object to a map of input values, in the form the inputs of a REST API request are decoded to,
+// i.e. lists become []interface{} and numbers float64
+func scheduleInputValues(inputs cty.Value) (map[string]interface{}, error) {
+	if inputs.IsNull() {
+		return nil, nil
+	}
+	if !inputs.Type().IsObjectType() && !inputs.Type().IsMapType() {
+		return nil, fmt.Errorf("inputs must be an object")
+	}
+	inputsJson, err := utils.CtyToJSON(inputs)
+	if err != nil {
+		return nil, err
+	}
+	var res map[string]interface{}
+	if err := json.Unmarshal([]byte(inputsJson), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
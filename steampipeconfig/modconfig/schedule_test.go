@@ -0,0 +1,51 @@
+package modconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+type scheduleInputValuesTest struct {
+	inputs   cty.Value
+	expected map[string]interface{}
+}
+
+var testCasesScheduleInputValues = map[string]scheduleInputValuesTest{
+	"not set": {
+		inputs:   cty.NullVal(cty.DynamicPseudoType),
+		expected: nil,
+	},
+	"mixed types": {
+		inputs: cty.ObjectVal(map[string]cty.Value{
+			"input.region":  cty.StringVal("us-east-1"),
+			"input.regions": cty.TupleVal([]cty.Value{cty.StringVal("us-east-1"), cty.StringVal("us-east-2")}),
+			"input.range":   cty.TupleVal([]cty.Value{cty.StringVal("2022-01-01"), cty.StringVal("2022-02-01")}),
+			"input.count":   cty.NumberIntVal(3),
+		}),
+		expected: map[string]interface{}{
+			"input.region":  "us-east-1",
+			"input.regions": []interface{}{"us-east-1", "us-east-2"},
+			"input.range":   []interface{}{"2022-01-01", "2022-02-01"},
+			"input.count":   float64(3),
+		},
+	},
+}
+
+func TestScheduleInputValues(t *testing.T) {
+	for name, test := range testCasesScheduleInputValues {
+		res, err := scheduleInputValues(test.inputs)
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, res)
+		}
+	}
+
+	if _, err := scheduleInputValues(cty.StringVal("us-east-1")); err == nil {
+		t.Error("expected an error for inputs which are not an object")
+	}
+}
@@ -17,6 +17,11 @@ type Terminal struct {
 	SearchPath       *string `hcl:"search_path"`
 	SearchPathPrefix *string `hcl:"search_path_prefix"`
 	Watch            *bool   `hcl:"watch"`
+	// check text output options
+	CheckDisplayWidth      *int    `hcl:"check_display_width"`
+	CheckDisplayColumns    *string `hcl:"check_display_columns"`
+	CheckDisplayDimensions *string `hcl:"check_display_dimensions"`
+	OnlyFailed             *bool   `hcl:"only_failed"`
 }
 
 // ConfigMap :: create a config map to pass to viper
@@ -49,6 +54,20 @@ func (t *Terminal) ConfigMap() map[string]interface{} {
 	if t.Watch != nil {
 		res[constants.ArgWatch] = t.Watch
 	}
+	if t.CheckDisplayWidth != nil {
+		res[constants.ArgCheckDisplayWidth] = t.CheckDisplayWidth
+	}
+	if t.CheckDisplayColumns != nil {
+		// convert from string to array
+		res[constants.ArgCheckDisplayColumns] = searchPathToArray(*t.CheckDisplayColumns)
+	}
+	if t.CheckDisplayDimensions != nil {
+		// convert from string to array
+		res[constants.ArgCheckDisplayDimensions] = searchPathToArray(*t.CheckDisplayDimensions)
+	}
+	if t.OnlyFailed != nil {
+		res[constants.ArgOnlyFailed] = t.OnlyFailed
+	}
 	return res
 }
 
@@ -81,6 +100,18 @@ func (t *Terminal) Merge(otherOptions Options) {
 		if o.Watch != nil {
 			t.Watch = o.Watch
 		}
+		if o.CheckDisplayWidth != nil {
+			t.CheckDisplayWidth = o.CheckDisplayWidth
+		}
+		if o.CheckDisplayColumns != nil {
+			t.CheckDisplayColumns = o.CheckDisplayColumns
+		}
+		if o.CheckDisplayDimensions != nil {
+			t.CheckDisplayDimensions = o.CheckDisplayDimensions
+		}
+		if o.OnlyFailed != nil {
+			t.OnlyFailed = o.OnlyFailed
+		}
 	}
 }
 
@@ -129,6 +160,26 @@ func (t *Terminal) String() string {
 	} else {
 		str = append(str, fmt.Sprintf("  Watch: %v", *t.Watch))
 	}
+	if t.CheckDisplayWidth == nil {
+		str = append(str, "  CheckDisplayWidth: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  CheckDisplayWidth: %d", *t.CheckDisplayWidth))
+	}
+	if t.CheckDisplayColumns == nil {
+		str = append(str, "  CheckDisplayColumns: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  CheckDisplayColumns: %s", *t.CheckDisplayColumns))
+	}
+	if t.CheckDisplayDimensions == nil {
+		str = append(str, "  CheckDisplayDimensions: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  CheckDisplayDimensions: %s", *t.CheckDisplayDimensions))
+	}
+	if t.OnlyFailed == nil {
+		str = append(str, "  OnlyFailed: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  OnlyFailed: %v", *t.OnlyFailed))
+	}
 	return strings.Join(str, "\n")
 }
 
@@ -0,0 +1,17 @@
+package parse
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// DecodeNotifier decodes a notifier block
+func DecodeNotifier(block *hcl.Block) (*modconfig.Notifier, hcl.Diagnostics) {
+	notifier := modconfig.NewNotifier(block)
+	diags := gohcl.DecodeBody(block.Body, nil, notifier)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return notifier, nil
+}
@@ -220,6 +220,11 @@ func ParseMod(modPath string, fileData map[string][]byte, pseudoResources []modc
 		prevUnresolvedBlocks = unresolvedBlocks
 	}
 
+	// check the control dependencies do not contain any cycles
+	if diags = mod.ValidateControlDependencies(); diags.HasErrors() {
+		return nil, plugin.DiagsToError("Failed to load mod", diags)
+	}
+
 	// now tell mod to build tree of controls.
 	if err := mod.BuildResourceTree(runCtx.LoadedDependencyMods); err != nil {
 		return nil, err
@@ -0,0 +1,17 @@
+package parse
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/turbot/steampipe/steampipeconfig/modconfig"
+)
+
+// DecodeSchedule decodes a schedule block
+func DecodeSchedule(block *hcl.Block) (*modconfig.Schedule, hcl.Diagnostics) {
+	schedule := modconfig.NewSchedule(block)
+	diags := gohcl.DecodeBody(block.Body, nil, schedule)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return schedule, nil
+}
@@ -16,6 +16,14 @@ var ConfigBlockSchema = &hcl.BodySchema{
 			Type:       "options",
 			LabelNames: []string{"type"},
 		},
+		{
+			Type:       "notifier",
+			LabelNames: []string{"name"},
+		},
+		{
+			Type:       "schedule",
+			LabelNames: []string{"name"},
+		},
 	},
 }
 
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-version"
@@ -21,6 +22,10 @@ import (
 type SteampipeConfig struct {
 	// map of connection name to partially parsed connection config
 	Connections map[string]*modconfig.Connection
+	// map of notifier name to check notifier config
+	Notifiers map[string]*modconfig.Notifier
+	// map of schedule name to scheduled dashboard/benchmark run config
+	Schedules map[string]*modconfig.Schedule
 
 	// Steampipe options
 	DefaultConnectionOptions *options.Connection
@@ -33,6 +38,8 @@ type SteampipeConfig struct {
 func NewSteampipeConfig(commandName string) *SteampipeConfig {
 	return &SteampipeConfig{
 		Connections: make(map[string]*modconfig.Connection),
+		Notifiers:   make(map[string]*modconfig.Notifier),
+		Schedules:   make(map[string]*modconfig.Schedule),
 		commandName: commandName,
 	}
 }
@@ -48,6 +55,12 @@ func (c *SteampipeConfig) Validate() error {
 		}
 		validationErrors = append(validationErrors, connection.Validate(c.Connections)...)
 	}
+	for _, notifier := range c.Notifiers {
+		validationErrors = append(validationErrors, notifier.Validate()...)
+	}
+	for _, schedule := range c.Schedules {
+		validationErrors = append(validationErrors, schedule.Validate()...)
+	}
 	if len(validationErrors) > 0 {
 		return fmt.Errorf("config validation failed with %d %s: \n  - %s", len(validationErrors), utils.Pluralize("error", len(validationErrors)), strings.Join(validationErrors, "\n  - "))
 	}
@@ -255,6 +268,26 @@ func (c *SteampipeConfig) ConnectionsForPlugin(pluginLongName string, pluginVers
 	return res
 }
 
+// NotifierList returns a list of the configured notifiers, sorted by name
+func (c *SteampipeConfig) NotifierList() []*modconfig.Notifier {
+	res := make([]*modconfig.Notifier, 0, len(c.Notifiers))
+	for _, n := range c.Notifiers {
+		res = append(res, n)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// ScheduleList returns a list of the configured schedules, sorted by name
+func (c *SteampipeConfig) ScheduleList() []*modconfig.Schedule {
+	res := make([]*modconfig.Schedule, 0, len(c.Schedules))
+	for _, s := range c.Schedules {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
 // ConnectionNames returns a flat list of connection names
 func (c *SteampipeConfig) ConnectionNames() []string {
 	res := make([]string, len(c.Connections))
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed standard 5 field cron expression: minute hour day-of-month month day-of-week
+type CronSchedule struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+	// when both day fields are restricted, a time matches if either matches (as with standard cron)
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCronSchedule parses a cron expression
+// each field may be '*', a value, a range 'a-b', a step '*/n' or 'a-b/n', or a comma separated list of these
+// the macros @yearly, @monthly, @weekly, @daily and @hourly are also supported
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 fields, got %d", expr, len(fields))
+	}
+
+	var err error
+	s := &CronSchedule{
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}
+	bounds := []struct {
+		dest     *map[int]bool
+		name     string
+		min, max int
+	}{
+		{&s.minutes, "minute", 0, 59},
+		{&s.hours, "hour", 0, 23},
+		{&s.daysOfMonth, "day of month", 1, 31},
+		{&s.months, "month", 1, 12},
+		// allow 7 as an alias for sunday
+		{&s.daysOfWeek, "day of week", 0, 7},
+	}
+	for i, b := range bounds {
+		if *b.dest, err = parseCronField(fields[i], b.min, b.max); err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': invalid %s: %s", expr, b.name, err.Error())
+		}
+	}
+	if s.daysOfWeek[7] {
+		s.daysOfWeek[0] = true
+	}
+	return s, nil
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		hasStep := false
+		if idx := strings.Index(part, "/"); idx != -1 {
+			hasStep = true
+			rangePart = part[:idx]
+			var err error
+			if step, err = strconv.Atoi(part[idx+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step '%s'", part[idx+1:])
+			}
+		}
+
+		start, end := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid value '%s'", bounds[0])
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("invalid value '%s'", bounds[1])
+				}
+			} else if hasStep {
+				// 'a/n' means every n from a (so 'a/1' means every value from a)
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return nil, fmt.Errorf("'%s' is out of range %d-%d", part, min, max)
+		}
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// Next returns the first time after t which matches the schedule, in the location of t
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	// start from the next whole minute
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// every valid schedule matches at least once in any 5 year period (leap days included)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !s.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	// the schedule can never match (e.g. 30th February)
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.daysOfMonth[t.Day()]
+	dowMatch := s.daysOfWeek[int(t.Weekday())]
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dowMatch
+	case s.anyDayOfWeek:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+type cronTest struct {
+	expr     string
+	from     string
+	expected string
+}
+
+var testCasesCronNext = map[string]cronTest{
+	"every minute": {
+		expr:     "* * * * *",
+		from:     "2022-06-01T10:15:30Z",
+		expected: "2022-06-01T10:16:00Z",
+	},
+	"hourly macro": {
+		expr:     "@hourly",
+		from:     "2022-06-01T10:15:00Z",
+		expected: "2022-06-01T11:00:00Z",
+	},
+	"daily at 2am": {
+		expr:     "0 2 * * *",
+		from:     "2022-06-01T10:15:00Z",
+		expected: "2022-06-02T02:00:00Z",
+	},
+	"step minutes": {
+		expr:     "*/20 * * * *",
+		from:     "2022-06-01T10:41:00Z",
+		expected: "2022-06-01T11:00:00Z",
+	},
+	"step of one from start": {
+		// '50/1' means every minute from 50
+		expr:     "50/1 * * * *",
+		from:     "2022-06-01T10:51:00Z",
+		expected: "2022-06-01T10:52:00Z",
+	},
+	"weekdays range": {
+		// 2022-06-04 is a saturday
+		expr:     "30 9 * * 1-5",
+		from:     "2022-06-04T00:00:00Z",
+		expected: "2022-06-06T09:30:00Z",
+	},
+	"sunday as 7": {
+		expr:     "0 0 * * 7",
+		from:     "2022-06-01T00:00:00Z",
+		expected: "2022-06-05T00:00:00Z",
+	},
+	"day of month or day of week": {
+		// the 15th, or any monday - 2022-06-06 is a monday
+		expr:     "0 0 15 * 1",
+		from:     "2022-06-01T00:00:00Z",
+		expected: "2022-06-06T00:00:00Z",
+	},
+	"month rollover": {
+		expr:     "0 0 1 1,7 *",
+		from:     "2022-06-01T00:00:00Z",
+		expected: "2022-07-01T00:00:00Z",
+	},
+	"leap day": {
+		expr:     "0 0 29 2 *",
+		from:     "2022-03-01T00:00:00Z",
+		expected: "2024-02-29T00:00:00Z",
+	},
+	"never": {
+		expr:     "0 0 30 2 *",
+		from:     "2022-03-01T00:00:00Z",
+		expected: "0001-01-01T00:00:00Z",
+	},
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	for name, test := range testCasesCronNext {
+		schedule, err := ParseCronSchedule(test.expr)
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error %v", name, err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339, test.from)
+		next := schedule.Next(from).Format(time.RFC3339)
+		if next != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", name, test.expected, next)
+		}
+	}
+}
+
+var testCasesCronInvalid = map[string]string{
+	"too few fields":   "* * * *",
+	"minute too large": "60 * * * *",
+	"bad step":         "*/0 * * * *",
+	"inverted range":   "* 5-2 * * *",
+	"not a number":     "* * x * *",
+	"day of month 0":   "* * 0 * *",
+}
+
+func TestParseCronScheduleInvalid(t *testing.T) {
+	for name, expr := range testCasesCronInvalid {
+		if _, err := ParseCronSchedule(expr); err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected an error parsing '%s'", name, expr)
+		}
+	}
+}
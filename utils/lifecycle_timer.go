@@ -26,3 +26,21 @@ func (r LifecycleTimer) GetDuration() time.Duration {
 func (r *LifecycleTimer) Add(event string) {
 	r.events = append(r.events, &LifecycleEvent{event, time.Now()})
 }
+
+// GetDurationBetween returns the duration between the first occurrence of the start event
+// and the last occurrence of the end event - or zero if either event was not recorded
+func (r LifecycleTimer) GetDurationBetween(start, end string) time.Duration {
+	var startTime, endTime *time.Time
+	for _, e := range r.events {
+		if e.Event == start && startTime == nil {
+			startTime = &e.Time
+		}
+		if e.Event == end {
+			endTime = &e.Time
+		}
+	}
+	if startTime == nil || endTime == nil || endTime.Before(*startTime) {
+		return 0
+	}
+	return endTime.Sub(*startTime)
+}